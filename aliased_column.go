@@ -0,0 +1,16 @@
+package qubr
+
+import "fmt"
+
+// aliasedColumn is a column of a select which is an expression, rather than a field, and is named by its alias.
+type aliasedColumn struct {
+	expr  string
+	args  []any
+	alias string
+
+	raw bool // The expression is raw SQL, from SelectBuilder.ColumnRaw.
+}
+
+func (c aliasedColumn) String() string {
+	return fmt.Sprintf(`%s AS "%s"`, c.expr, c.alias)
+}
@@ -0,0 +1,76 @@
+package qubr
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"strings"
+)
+
+// Batch is a QueryBuilder which combines the queries of many QueryBuilders into a single script, such as for a
+// migration. Each query keeps its own terminating semicolon, and is placed on its own line. The args of each query are
+// appended in the same order as the queries, which lines up with the positional placeholders.
+// Not every driver supports running many statements at once, but the script from Batch.BuildQuery can still be written
+// out to a file.
+// Example:
+//
+//	_, err := NewBatch(
+//		Delete[User]().Where(Equal("ID", 42)),
+//		Delete[Order]().Where(Equal("UserID", 42)),
+//	).ExecContext(ctx, db)
+type Batch struct {
+	builders []QueryBuilder
+}
+
+// NewBatch will construct a new Batch of the builders given.
+func NewBatch(builders ...QueryBuilder) Batch {
+	return Batch{builders: builders}
+}
+
+// Add will add more builders to the end of the Batch.
+func (b Batch) Add(builders ...QueryBuilder) Batch {
+	b.builders = append(slices.Clip(b.builders), builders...)
+	return b
+}
+
+// BuildQuery will construct the combined SQL script of the queries of the Batch, along with all of their args.
+// If any of the builders return an error, then that error is returned. An empty Batch results in ErrEmptyBatch.
+//
+// The resulting query should look something like:
+//
+//	DELETE FROM "table1" WHERE "field1" = ?;
+//	DELETE FROM "table2" WHERE "field2" = ?;
+func (b Batch) BuildQuery() (query string, args []any, err error) {
+	if len(b.builders) == 0 {
+		return "", nil, ErrEmptyBatch
+	}
+
+	queries := make([]string, 0, len(b.builders))
+	for _, builder := range b.builders {
+		q, a, err := builder.BuildQuery()
+		if err != nil {
+			return "", nil, err
+		}
+
+		queries = append(queries, q)
+		args = append(args, a...)
+	}
+
+	return strings.Join(queries, "\n"), args, nil
+}
+
+// Exec wraps Batch.ExecContext, which will execute the combined script of the Batch.
+func (b Batch) Exec(db Executor) (sql.Result, error) {
+	return b.ExecContext(context.Background(), db)
+}
+
+// ExecContext will execute the combined script of the Batch, using the provided Executor.
+// The driver must support many statements in a single exec, otherwise, run each builder on its own, within a transaction.
+func (b Batch) ExecContext(ctx context.Context, db Executor) (sql.Result, error) {
+	query, args, err := b.BuildQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	return execContext(ctx, db, query, args)
+}
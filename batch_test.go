@@ -0,0 +1,62 @@
+package qubr
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestBatch(t *testing.T) {
+	type bunny struct {
+		Name string
+		Age  int64
+	}
+
+	query, args, err := NewBatch(
+		Insert[bunny]().Values(bunny{"ollie", 2}),
+		Update[bunny]().Set("Age", 3).Where(Equal("Name", "ollie")),
+	).
+		Add(Delete[bunny]().Where(GreaterThan("Age", 200))).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`INSERT INTO "bunny" VALUES (?, ?);`+"\n"+
+			`UPDATE "bunny" SET "Age" = ? WHERE "Name" = ?;`+"\n"+
+			`DELETE FROM "bunny" WHERE "Age" > ?;`,
+		query,
+	)
+	assert.Equal(t, []any{"ollie", int64(2), 3, "ollie", 200}, args)
+}
+
+func TestBatchErrors(t *testing.T) {
+	type bunny struct {
+		Name string
+	}
+
+	_, _, err := NewBatch().BuildQuery()
+	assert.ErrorIs(t, err, ErrEmptyBatch)
+
+	_, _, err = NewBatch(Select[bunny](), Delete[bunny]().And(Equal("Name", "ollie"))).BuildQuery()
+	assert.ErrorIs(t, err, ErrMissingWhereClause)
+}
+
+func TestBatchAndExec(t *testing.T) {
+	type bunny struct {
+		Name string
+		Age  int64
+	}
+
+	db := SetupTestDatabase(t, `CREATE TABLE "bunny" ("Name" TEXT, "Age" INT);`)
+
+	_, err := NewBatch(
+		Insert[bunny]().Values(bunny{"ollie", 2}, bunny{"king ollie", 300}),
+		Update[bunny]().Set("Age", 3).Where(Equal("Name", "ollie")),
+		Delete[bunny]().Where(GreaterThan("Age", 200)),
+	).Exec(db)
+	assert.NoError(t, err)
+
+	bunnies, err := Select[bunny]().Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", 3}}, bunnies)
+}
@@ -0,0 +1,80 @@
+package qubr
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Case is a CASE expression, which results in the value of the first condition which is true, or the value of
+// Case.Else if there is none. Construct one using CaseWhen, and select it using SelectBuilder.CaseColumn.
+// Example:
+//
+//	Select[User]().
+//		CaseColumn(CaseWhen(GreaterThanOrEqual("Age", 18), "adult").Else("minor"), "Group")
+type Case struct {
+	whens []caseWhen
+	els   *any
+}
+
+// caseWhen is a single condition of a Case, and the value it results in.
+type caseWhen struct {
+	op     FieldOperation
+	result any
+}
+
+// CaseWhen will construct a new Case, which results in result when the condition op is true.
+func CaseWhen(op FieldOperation, result any) Case {
+	return Case{whens: []caseWhen{{op, result}}}
+}
+
+// When will add another condition to the Case, which is only checked if the conditions before it are false.
+func (c Case) When(op FieldOperation, result any) Case {
+	c.whens = append(slices.Clip(c.whens), caseWhen{op, result})
+	return c
+}
+
+// Else will set the result of the Case when none of its conditions are true. Without this, the result is NULL.
+func (c Case) Else(result any) Case {
+	c.els = &result
+	return c
+}
+
+// mapFieldNames will return a copy of the Case, where the field names of its conditions are replaced using fn.
+func (c Case) mapFieldNames(fn func(name string) string) Case {
+	whens := make([]caseWhen, len(c.whens))
+	for i, w := range c.whens {
+		w.op.FieldName = fn(w.op.FieldName)
+		whens[i] = w
+	}
+	c.whens = whens
+	return c
+}
+
+func (c Case) queryData() (string, []any, error) {
+	if len(c.whens) == 0 {
+		return "", nil, ErrEmptyCase
+	}
+
+	var args []any
+
+	sb := strings.Builder{}
+	sb.WriteString("CASE")
+	for _, w := range c.whens {
+		condition, conditionArgs, err := w.op.queryData()
+		if err != nil {
+			return "", nil, err
+		}
+
+		sb.WriteString(fmt.Sprintf(" WHEN %s THEN ?", condition))
+		args = append(args, conditionArgs...)
+		args = append(args, w.result)
+	}
+	if c.els != nil {
+		sb.WriteString(" ELSE ?")
+		args = append(args, *c.els)
+	}
+	sb.WriteString(" END")
+
+	return sb.String(), args, nil
+}
@@ -0,0 +1,42 @@
+package qubr
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCase_queryData(t *testing.T) {
+	tests := []struct {
+		name      string
+		c         Case
+		wantQuery string
+		wantArgs  []any
+		wantErr   error
+	}{
+		{
+			name:      "single condition",
+			c:         CaseWhen(GreaterThanOrEqual("Age", 18), "adult"),
+			wantQuery: `CASE WHEN "Age" >= ? THEN ? END`,
+			wantArgs:  []any{18, "adult"},
+		},
+		{
+			name:      "many conditions and else",
+			c:         CaseWhen(LessThan("Age", 1), "kit").When(In("Age", 1, 2), "young").Else("adult"),
+			wantQuery: `CASE WHEN "Age" < ? THEN ? WHEN "Age" IN (?, ?) THEN ? ELSE ? END`,
+			wantArgs:  []any{1, "kit", 1, 2, "young", "adult"},
+		},
+		{
+			name:    "no conditions",
+			c:       Case{},
+			wantErr: ErrEmptyCase,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotQuery, gotArgs, err := tt.c.queryData()
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantQuery, gotQuery)
+			assert.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
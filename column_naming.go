@@ -0,0 +1,40 @@
+package qubr
+
+import (
+	"strings"
+	"sync/atomic"
+	"unicode"
+)
+
+var snakeCaseColumns atomic.Bool
+
+// SetSnakeCaseColumns will enable or disable the conversion of Go field names to snake_case column names, for every
+// builder, and for mapping rows. For example, "EarLength" is the column "ear_length", and "UserID" is "user_id".
+// A name given in the "db" tag of a field is always used as it is. While enabled, the names given to the FieldOperation
+// of a where clause, SelectBuilder.WithFields, and UpdateBuilder.Set can be either the name of a field of the struct, or
+// the name of the column.
+func SetSnakeCaseColumns(enabled bool) {
+	snakeCaseColumns.Store(enabled)
+}
+
+// toSnakeCase will convert a Go name, such as "HTTPServerID", to snake_case, such as "http_server_id".
+// A run of upper case letters is treated as a single word, up until the last, which starts the next word.
+// A name which is already snake_case is unchanged.
+func toSnakeCase(name string) string {
+	r := []rune(name)
+
+	sb := strings.Builder{}
+	for i, c := range r {
+		if unicode.IsUpper(c) && i > 0 {
+			prev := r[i-1]
+			startsWord := unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				(unicode.IsUpper(prev) && i+1 < len(r) && unicode.IsLower(r[i+1]))
+			if startsWord {
+				sb.WriteRune('_')
+			}
+		}
+		sb.WriteRune(unicode.ToLower(c))
+	}
+
+	return sb.String()
+}
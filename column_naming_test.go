@@ -0,0 +1,114 @@
+package qubr
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_toSnakeCase(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Name", want: "name"},
+		{name: "EarLength", want: "ear_length"},
+		{name: "ID", want: "id"},
+		{name: "UserID", want: "user_id"},
+		{name: "HTTPServer", want: "http_server"},
+		{name: "HTTPServerID", want: "http_server_id"},
+		{name: "Carrots2Eat", want: "carrots2_eat"},
+		{name: "ear_length", want: "ear_length"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, toSnakeCase(tt.name))
+		})
+	}
+}
+
+func TestSetSnakeCaseColumns(t *testing.T) {
+	type bunny struct {
+		ID           int64
+		Name         string
+		EarLength    float64
+		FavoriteFood string `db:"FavoriteFood"`
+	}
+
+	SetSnakeCaseColumns(true)
+	t.Cleanup(func() { SetSnakeCaseColumns(false) })
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("id" INT, "name" TEXT, "ear_length" FLOAT, "FavoriteFood" TEXT);`,
+	)
+
+	_, err := Insert[bunny]().Values(bunny{1, "ollie", 15, "carrot"}).Exec(db)
+	assert.NoError(t, err)
+
+	query, args, err := Update[bunny]().
+		Set("EarLength", 16).
+		Where(Equal("ID", 1)).
+		BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `UPDATE "bunny" SET "ear_length" = ? WHERE "id" = ?;`, query)
+
+	_, err = db.Exec(query, args...)
+	assert.NoError(t, err)
+
+	bunnies, err := Select[bunny]().
+		Where(GreaterThan("ear_length", 15)).
+		Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{1, "ollie", 16, "carrot"}}, bunnies)
+
+	query, _, err = Select[bunny]().
+		WithFields("ID", "FavoriteFood").
+		Where(Equal("FavoriteFood", "carrot")).
+		AndGroup(func(g *Group) { g.Or(Equal("Name", "ollie")).Or(Equal("name", "oliver")) }).
+		BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`SELECT "id", "FavoriteFood" FROM "bunny" WHERE "FavoriteFood" = ? AND ("name" = ? OR "name" = ?);`,
+		query,
+	)
+
+	recorder := NewRecordingExecutor().StubRows([]string{"COUNT"}, []any{int64(1)})
+	defer recorder.Close()
+
+	_, err = Select[bunny]().Where(Equal("ID", 1)).CountDistinct("EarLength", recorder)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]RecordedQuery{{`SELECT COUNT(DISTINCT "ear_length") FROM "bunny" WHERE "id" = ?;`, []any{1}}},
+		recorder.Queries(),
+	)
+
+	query, _, err = Select[bunny]().
+		WithFields("ID").
+		CaseColumn(CaseWhen(GreaterThan("EarLength", 15), "long").Else("short"), "FavoriteFood").
+		BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`SELECT "id", CASE WHEN "ear_length" > ? THEN ? ELSE ? END AS "FavoriteFood" FROM "bunny";`,
+		query,
+	)
+}
+
+func TestSetSnakeCaseColumnsOrderBy(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	SetSnakeCaseColumns(true)
+	t.Cleanup(func() { SetSnakeCaseColumns(false) })
+
+	query, _, err := Select[bunny]().
+		OrderBy("EarLength", Descending).
+		OrderBy("Name", Ascending).
+		BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "name", "ear_length" FROM "bunny" ORDER BY "ear_length" DESC, "name" ASC;`, query)
+}
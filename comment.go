@@ -0,0 +1,38 @@
+package qubr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// commentPrefix will construct a leading SQL comment for a query. If the comment is empty, nothing is returned.
+// Anything which would open or close a comment is removed, so the comment cannot be broken out of.
+func commentPrefix(comment string) string {
+	comment = sanitizeComment(comment)
+	if comment == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("/* %s */ ", comment)
+}
+
+// hintComment will construct an optimizer hint comment, to be placed directly after the SELECT keyword.
+// If the hint is empty, nothing is returned. Like commentPrefix, the hint cannot be broken out of.
+func hintComment(hint string) string {
+	hint = sanitizeComment(hint)
+	if hint == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("/*+ %s */ ", hint)
+}
+
+// sanitizeComment will remove anything which would open or close a comment, along with surrounding whitespace.
+func sanitizeComment(comment string) string {
+	for strings.Contains(comment, "*/") || strings.Contains(comment, "/*") {
+		comment = strings.ReplaceAll(comment, "*/", "")
+		comment = strings.ReplaceAll(comment, "/*", "")
+	}
+
+	return strings.TrimSpace(comment)
+}
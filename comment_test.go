@@ -0,0 +1,44 @@
+package qubr
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_commentPrefix(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		want    string
+	}{
+		{name: "empty", comment: "", want: ""},
+		{name: "simple", comment: "route=GetBunny", want: "/* route=GetBunny */ "},
+		{name: "close comment", comment: "route=GetBunny */ DROP TABLE bunny; --", want: "/* route=GetBunny  DROP TABLE bunny; -- */ "},
+		{name: "nested comment", comment: "/* route */", want: "/* route */ "},
+		{name: "rebuilt close comment", comment: "**//", want: ""},
+		{name: "whitespace", comment: "  ", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, commentPrefix(tt.comment))
+		})
+	}
+}
+
+func Test_hintComment(t *testing.T) {
+	tests := []struct {
+		name string
+		hint string
+		want string
+	}{
+		{name: "empty", hint: "", want: ""},
+		{name: "simple", hint: "MAX_EXECUTION_TIME(1000)", want: "/*+ MAX_EXECUTION_TIME(1000) */ "},
+		{name: "close comment", hint: "NO_ICP(bunny) */ DROP TABLE bunny; --", want: "/*+ NO_ICP(bunny)  DROP TABLE bunny; -- */ "},
+		{name: "whitespace", hint: "  ", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, hintComment(tt.hint))
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"reflect"
+	"time"
 )
 
 // DeleteBuilder is a QueryBuilder for building SQL DELETE queries.
@@ -24,6 +25,16 @@ type DeleteBuilder[T any] struct {
 
 	limit *uint64
 
+	timeFormat TimeFormat
+
+	comment string
+
+	timeout time.Duration
+
+	strictExportedFields bool
+
+	safeMode bool
+
 	err error
 }
 
@@ -53,21 +64,83 @@ func (b DeleteBuilder[T]) From(tableName string) DeleteBuilder[T] {
 // Where will apply a FieldOperation as the initial comparison operator of a where clause.
 // Where cannot be called more than once, use DeleteBuilder.And or DeleteBuilder.Or for further filtering.
 func (b DeleteBuilder[T]) Where(op FieldOperation) DeleteBuilder[T] {
-	if b.fieldOperationTree != emptyFieldOperationTree {
+	if !b.fieldOperationTree.isEmpty() {
+		b.err = ErrDoubleWhereClause
+		return b
+	}
+
+	b.fieldOperationTree = fieldOperationTree{entries: []fieldOperationEntry{{op: op}}}
+
+	return b
+}
+
+// WhereExample will apply an Equal for each exported field of the example, which is not the zero value for its type,
+// as the initial conditions of a where clause. These are all joined with AND.
+// Since zero values are skipped, this cannot be used to match on a zero value, use DeleteBuilder.And for these.
+// To avoid accidentally deleting everything, an example with only zero values will result in ErrEmptyExample.
+// Like DeleteBuilder.Where, this cannot be called more than once, or along with DeleteBuilder.Where.
+func (b DeleteBuilder[T]) WhereExample(example T) DeleteBuilder[T] {
+	if !b.fieldOperationTree.isEmpty() {
+		b.err = ErrDoubleWhereClause
+		return b
+	}
+
+	tree, err := newExampleFieldOperationTree(example)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	b.fieldOperationTree = tree
+	return b
+}
+
+// ByIDs will apply an In on the column, for each of the ids, as the initial condition of a where clause. This is the
+// same as using DeleteBuilder.Where with In, which splits the ids across many INs, joined by OR, when there are more than 500.
+// Unlike In, a single id which is a QueryBuilder is not used as a subquery. No ids will result in ErrNoIDs.
+// Like DeleteBuilder.Where, this cannot be called more than once, or along with DeleteBuilder.Where.
+// Equivalent SQL will be:
+//
+//	WHERE "column" IN (?, ...)
+//	WHERE ("column" IN (?, ...) OR "column" IN (?, ...))
+func (b DeleteBuilder[T]) ByIDs(column string, ids ...any) DeleteBuilder[T] {
+	if !b.fieldOperationTree.isEmpty() {
 		b.err = ErrDoubleWhereClause
 		return b
 	}
 
-	b.fieldOperationTree.op = op
+	tree, err := newIDsFieldOperationTree(column, ids)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	b.fieldOperationTree = tree
+	return b
+}
+
+// WhereGroup will apply a Group as the initial condition of a where clause, built using fn.
+// Like DeleteBuilder.Where, this cannot be called more than once, or along with DeleteBuilder.Where.
+func (b DeleteBuilder[T]) WhereGroup(fn func(g *Group)) DeleteBuilder[T] {
+	if !b.fieldOperationTree.isEmpty() {
+		b.err = ErrDoubleWhereClause
+		return b
+	}
+
+	group, err := newFieldOperationGroup(fn)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	b.fieldOperationTree = fieldOperationTree{entries: []fieldOperationEntry{{group: group}}}
 
 	return b
 }
 
 // And will apply an AND to the existing where clause. DeleteBuilder.Where must be called before this.
 func (b DeleteBuilder[T]) And(op FieldOperation) DeleteBuilder[T] {
-	err := appendToFieldOperationTree(&b.fieldOperationTree, func(next *fieldOperationTree) {
-		next.and = &fieldOperationTree{op: op}
-	})
+	err := appendToFieldOperationTree(&b.fieldOperationTree, fieldOperationEntry{connector: connectorAnd, op: op})
 	if err != nil {
 		b.err = err
 	}
@@ -76,9 +149,71 @@ func (b DeleteBuilder[T]) And(op FieldOperation) DeleteBuilder[T] {
 
 // Or will apply an OR to the existing where clause. DeleteBuilder.Where must be called before this.
 func (b DeleteBuilder[T]) Or(op FieldOperation) DeleteBuilder[T] {
-	err := appendToFieldOperationTree(&b.fieldOperationTree, func(next *fieldOperationTree) {
-		next.or = &fieldOperationTree{op: op}
-	})
+	err := appendToFieldOperationTree(&b.fieldOperationTree, fieldOperationEntry{connector: connectorOr, op: op})
+	if err != nil {
+		b.err = err
+	}
+	return b
+}
+
+// AndRaw will apply an AND to the existing where clause, with a condition of raw SQL, and the args for any of its
+// placeholders. The condition is rendered within parentheses. DeleteBuilder.Where must be called before this.
+// The expression is placed into the query as it is, so it must never contain untrusted input. Instead, use
+// placeholders, and pass the input as args.
+func (b DeleteBuilder[T]) AndRaw(expr string, args ...any) DeleteBuilder[T] {
+	raw, err := newRawCondition(expr, args)
+	if err == nil {
+		err = appendToFieldOperationTree(&b.fieldOperationTree, fieldOperationEntry{connector: connectorAnd, raw: raw})
+	}
+	if err != nil {
+		b.err = err
+	}
+	return b
+}
+
+// OrRaw will apply an OR to the existing where clause, with a condition of raw SQL, and the args for any of its
+// placeholders. The condition is rendered within parentheses. DeleteBuilder.Where must be called before this.
+// Like DeleteBuilder.AndRaw, the expression must never contain untrusted input.
+func (b DeleteBuilder[T]) OrRaw(expr string, args ...any) DeleteBuilder[T] {
+	raw, err := newRawCondition(expr, args)
+	if err == nil {
+		err = appendToFieldOperationTree(&b.fieldOperationTree, fieldOperationEntry{connector: connectorOr, raw: raw})
+	}
+	if err != nil {
+		b.err = err
+	}
+	return b
+}
+
+// NoWhere will clear any where clause that has been applied, so that a new one can be applied using DeleteBuilder.Where.
+// This is useful for deriving an unfiltered builder from one with filters. If there is no where clause, this does
+// nothing.
+// Be aware that a DeleteBuilder with no where clause will delete every row in the table.
+func (b DeleteBuilder[T]) NoWhere() DeleteBuilder[T] {
+	b.fieldOperationTree = fieldOperationTree{}
+	return b
+}
+
+// AndGroup will apply an AND to the existing where clause, with a Group built using fn.
+// The Group will be rendered within parentheses. DeleteBuilder.Where must be called before this.
+func (b DeleteBuilder[T]) AndGroup(fn func(g *Group)) DeleteBuilder[T] {
+	group, err := newFieldOperationGroup(fn)
+	if err == nil {
+		err = appendToFieldOperationTree(&b.fieldOperationTree, fieldOperationEntry{connector: connectorAnd, group: group})
+	}
+	if err != nil {
+		b.err = err
+	}
+	return b
+}
+
+// OrGroup will apply an OR to the existing where clause, with a Group built using fn.
+// The Group will be rendered within parentheses. DeleteBuilder.Where must be called before this.
+func (b DeleteBuilder[T]) OrGroup(fn func(g *Group)) DeleteBuilder[T] {
+	group, err := newFieldOperationGroup(fn)
+	if err == nil {
+		err = appendToFieldOperationTree(&b.fieldOperationTree, fieldOperationEntry{connector: connectorOr, group: group})
+	}
 	if err != nil {
 		b.err = err
 	}
@@ -99,6 +234,44 @@ func (b DeleteBuilder[T]) Limit(n uint64) DeleteBuilder[T] {
 	return b
 }
 
+// StoreTimeAs will set the TimeFormat which time.Time values in the where clause are compared as, which should match
+// how they are stored. By default, this is TimeFormatNative.
+func (b DeleteBuilder[T]) StoreTimeAs(f TimeFormat) DeleteBuilder[T] {
+	b.timeFormat = f
+	return b
+}
+
+// WithComment will place a comment at the start of the query, which is useful for tracing queries back to the code
+// which ran them, such as in slow query logs. Anything which would close the comment early is removed.
+// Equivalent SQL will be:
+//
+//	/* comment */ ...
+func (b DeleteBuilder[T]) WithComment(comment string) DeleteBuilder[T] {
+	b.comment = comment
+	return b
+}
+
+// Timeout will set a deadline for running the query, when it is run without a context, such as by DeleteBuilder.Exec.
+// If a context is given, such as to DeleteBuilder.ExecContext, then the timeout is not used.
+func (b DeleteBuilder[T]) Timeout(d time.Duration) DeleteBuilder[T] {
+	b.timeout = d
+	return b
+}
+
+// StrictExportedFields will cause DeleteBuilder.BuildQuery to return an ErrUnexportedField if T has any unexported fields.
+// By default, unexported fields are silently skipped.
+func (b DeleteBuilder[T]) StrictExportedFields() DeleteBuilder[T] {
+	b.strictExportedFields = true
+	return b
+}
+
+// SafeMode will cause DeleteBuilder.BuildQuery to return an ErrRawInSafeMode if any method which places raw SQL into the query
+// has been used, regardless of whether it was called before or after this. See SetSafeMode for these methods.
+func (b DeleteBuilder[T]) SafeMode() DeleteBuilder[T] {
+	b.safeMode = true
+	return b
+}
+
 // BuildQuery will construct the SQL query DeleteBuilder is currently representing.
 // User input will utilize placeholders, and the values of the input will be in the 2nd return value, args.
 // If there was an issue in the construction of DeleteBuilder, then the 3rd return value, err will not non-nil.
@@ -110,10 +283,27 @@ func (b DeleteBuilder[T]) BuildQuery() (query string, args []any, err error) {
 	if b.err != nil {
 		return "", nil, b.err
 	}
+	if err := checkStructType(reflect.TypeFor[T]()); err != nil {
+		return "", nil, err
+	}
+	if err := checkWritable[T](); err != nil {
+		return "", nil, err
+	}
+	if b.strictExportedFields {
+		if err := checkExportedFields(reflect.TypeFor[T]()); err != nil {
+			return "", nil, err
+		}
+	}
+	if (b.safeMode || safeMode.Load()) && b.hasRaw() {
+		return "", nil, ErrRawInSafeMode
+	}
 
 	tableName := b.from.String()
 
-	whereClause, whereArgs := b.fieldOperationTree.buildQuery()
+	whereClause, whereArgs, err := b.fieldOperationTree.mapFieldNames(fieldColumnName[T]).encodeTimes(b.timeFormat).buildQuery()
+	if err != nil {
+		return "", nil, err
+	}
 	args = append(args, whereArgs...)
 
 	var limit string
@@ -122,21 +312,29 @@ func (b DeleteBuilder[T]) BuildQuery() (query string, args []any, err error) {
 		args = append(args, *b.limit)
 	}
 
-	return fmt.Sprintf("DELETE FROM %s%s%s;", tableName, whereClause, limit), args, nil
+	return fmt.Sprintf("%sDELETE FROM %s%s%s;", commentPrefix(b.comment), tableName, whereClause, limit), args, nil
+}
+
+// hasRaw will report whether the delete has any raw SQL within its conditions.
+func (b DeleteBuilder[T]) hasRaw() bool {
+	return b.fieldOperationTree.hasRaw()
 }
 
 // Exec wraps DeleteBuilder.ExecContext, which will execute the delete query represented by the DeleteBuilder.
-func (b DeleteBuilder[T]) Exec(db *sql.DB) (sql.Result, error) {
-	return b.ExecContext(context.Background(), db)
+func (b DeleteBuilder[T]) Exec(db Executor) (sql.Result, error) {
+	ctx, cancel := timeoutContext(b.timeout)
+	defer cancel()
+
+	return b.ExecContext(ctx, db)
 }
 
 // ExecContext will execute the delete query represented by DeleteBuilder.
-// This will execute using the provided sql.DB, and the response is simply passed back.
-func (b DeleteBuilder[T]) ExecContext(ctx context.Context, db *sql.DB) (sql.Result, error) {
+// This will execute using the provided Executor, and the response is simply passed back.
+func (b DeleteBuilder[T]) ExecContext(ctx context.Context, db Executor) (sql.Result, error) {
 	query, args, err := b.BuildQuery()
 	if err != nil {
 		return nil, err
 	}
 
-	return db.ExecContext(ctx, query, args...)
+	return execContext(ctx, db, query, args)
 }
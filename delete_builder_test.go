@@ -1,7 +1,10 @@
 package qubr
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -103,3 +106,150 @@ func TestDeleteAndExec(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, int64(1), affected)
 }
+
+func TestDeleteStrictExportedFields(t *testing.T) {
+	type food struct {
+		Name       string
+		Kilojoules float64
+
+		secretIngredient string
+	}
+
+	_, _, err := Delete[food]().
+		StrictExportedFields().
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrUnexportedField{"secretIngredient"}, err)
+
+	type plainFood struct {
+		Name       string
+		Kilojoules float64
+	}
+
+	query, _, err := Delete[plainFood]().
+		StrictExportedFields().
+		Where(Equal("Name", "donut")).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `DELETE FROM "plainFood" WHERE "Name" = ?;`, query)
+}
+
+func TestDeleteWithGroupedFilter(t *testing.T) {
+	type food struct {
+		Name       string
+		Kilojoules float64
+	}
+
+	query, args, err := Delete[food]().
+		Where(Equal("Name", "mold")).
+		OrGroup(func(g *Group) {
+			g.And(LessThan("Kilojoules", 415)).And(NotEqual("Name", "celery"))
+		}).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `DELETE FROM "food" WHERE "Name" = ? OR ("Kilojoules" < ? AND "Name" <> ?);`, query)
+	assert.Equal(t, []any{"mold", 415, "celery"}, args)
+}
+
+func TestDeleteWhereExample(t *testing.T) {
+	type food struct {
+		Name       string `db:"name"`
+		Kilojoules float64
+		Brand      string
+	}
+
+	query, args, err := Delete[food]().
+		WhereExample(food{Name: "donut", Brand: "dunkin"}).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `DELETE FROM "food" WHERE "name" = ? AND "Brand" = ?;`, query)
+	assert.Equal(t, []any{"donut", "dunkin"}, args)
+}
+
+func TestDeleteWhereExampleAllZero(t *testing.T) {
+	type food struct {
+		Name       string
+		Kilojoules float64
+	}
+
+	_, _, err := Delete[food]().
+		WhereExample(food{}).
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrEmptyExample, err)
+}
+
+func TestDeleteNotAStruct(t *testing.T) {
+	_, _, err := Delete[int]().
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrNotAStruct{reflect.TypeFor[int]()}, err)
+}
+
+func TestDeleteNoWhereWithoutWhere(t *testing.T) {
+	type food struct {
+		Name       string
+		Kilojoules float64
+	}
+
+	query, args, err := Delete[food]().
+		NoWhere().
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `DELETE FROM "food";`, query)
+	assert.Empty(t, args)
+}
+
+func TestDeleteByIDs(t *testing.T) {
+	type bunny struct {
+		ID   int64
+		Name string
+	}
+
+	db := SetupMemoryTestDatabase(t, `CREATE TABLE "bunny" ("ID" INT, "Name" TEXT);`)
+
+	var bunnies []bunny
+	var ids []any
+	for i := range int64(1200) {
+		bunnies = append(bunnies, bunny{i, "ollie"})
+		ids = append(ids, i)
+	}
+	_, err := Insert[bunny]().Values(bunnies...).ExecBatched(context.Background(), db, 100)
+	assert.NoError(t, err)
+
+	// Every bunny but the last is deleted, which is more ids than fit in a single IN.
+	builder := Delete[bunny]().ByIDs("ID", ids[:1199]...)
+
+	query, args, err := builder.BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, strings.Count(query, `"ID" IN (`))
+	assert.True(t, strings.HasPrefix(query, `DELETE FROM "bunny" WHERE ("ID" IN (?, `))
+	assert.Contains(t, query, `?) OR "ID" IN (?, `)
+	assert.Len(t, args, 1199)
+
+	result, err := builder.Exec(db)
+	assert.NoError(t, err)
+	affected, err := result.RowsAffected()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1199), affected)
+
+	remaining, err := Select[bunny]().Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{1199, "ollie"}}, remaining)
+}
+
+func TestDeleteByIDsInvalid(t *testing.T) {
+	type bunny struct {
+		ID int64
+	}
+
+	_, _, err := Delete[bunny]().ByIDs("ID").BuildQuery()
+	assert.ErrorIs(t, err, ErrNoIDs)
+
+	_, _, err = Delete[bunny]().Where(Equal("ID", 42)).ByIDs("ID", 42).BuildQuery()
+	assert.ErrorIs(t, err, ErrDoubleWhereClause)
+}
@@ -3,6 +3,7 @@ package qubr
 import (
 	"errors"
 	"fmt"
+	"reflect"
 )
 
 var (
@@ -10,14 +11,40 @@ var (
 
 	ErrDoubleWhereClause  = errors.New("where clause is already present")
 	ErrMissingWhereClause = errors.New("where clause is not yet present")
+	ErrEmptyGroup         = errors.New("where clause group has no conditions")
+	ErrEmptyExample       = errors.New("example has no non-zero fields")
+	ErrNoIDs              = errors.New("no ids were given")
+	ErrEmptyRawCondition  = errors.New("raw condition has no expression")
+	ErrEmptyRawColumn     = errors.New("raw column has no expression")
+	ErrEmptyCase          = errors.New("case has no conditions")
 
-	ErrLimitAlreadySet = errors.New("limit value has already been set")
+	ErrNoJoinColumns = errors.New("join has no columns")
 
-	ErrNoInsertValues = errors.New("insert statement has no insert values")
+	ErrFilterMultipleValues = errors.New("filter param can only have one value")
+	ErrFilterRangeValues    = errors.New("filter param must have two values for a range")
+
+	ErrRawInSafeMode = errors.New("raw sql cannot be used in safe mode")
+
+	ErrLimitAlreadySet  = errors.New("limit value has already been set")
+	ErrOffsetAlreadySet = errors.New("offset value has already been set")
+
+	ErrInvalidPage = errors.New("page and page size must be greater than zero")
+
+	ErrNoInsertValues   = errors.New("insert statement has no insert values")
+	ErrInvalidBatchSize = errors.New("batch size must be greater than zero")
 
 	ErrNoSetStatement = errors.New("update statement has no insert values")
 
 	ErrNoRows = errors.New("get resulted in no rows")
+
+	ErrEmptyBatch = errors.New("batch has no queries")
+
+	ErrNoMoreResultSets = errors.New("query has no more result sets")
+
+	ErrUnexpectedPing = errors.New("ping query returned an unexpected result")
+
+	ErrNilSubquery          = errors.New("subquery has no query builder")
+	ErrScalarSubqueryFields = errors.New("scalar subquery must select exactly one field")
 )
 
 // ErrInvalidTableName occurs when a string provided cannot be used as a table name.
@@ -37,3 +64,144 @@ type ErrUnknownFieldName struct {
 func (e ErrUnknownFieldName) Error() string {
 	return fmt.Sprintf(`"%s" is not a valid field name`, e.Name)
 }
+
+// ErrUnexportedField occurs when strict exported fields are enforced, and the struct has an unexported field.
+type ErrUnexportedField struct {
+	Name string
+}
+
+func (e ErrUnexportedField) Error() string {
+	return fmt.Sprintf(`"%s" is an unexported field`, e.Name)
+}
+
+// ErrInvalidColumnName occurs when a string provided cannot be used as a column name.
+type ErrInvalidColumnName struct {
+	Name string
+}
+
+func (e ErrInvalidColumnName) Error() string {
+	return fmt.Sprintf(`"%s" is not a valid column name`, e.Name)
+}
+
+// ErrNotAStruct occurs when the type given to a builder is not a struct, so no fields can be determined from it.
+type ErrNotAStruct struct {
+	Type reflect.Type
+}
+
+func (e ErrNotAStruct) Error() string {
+	return fmt.Sprintf(`"%s" is not a struct type`, e.Type)
+}
+
+// ErrUnknownTransform occurs when a field's "transform" option names a transform which has not been registered.
+type ErrUnknownTransform struct {
+	Name string
+}
+
+func (e ErrUnknownTransform) Error() string {
+	return fmt.Sprintf(`"%s" is not a registered transform`, e.Name)
+}
+
+// ErrUnknownScope occurs when SelectBuilder.Scope names a scope which has not been registered for the type.
+type ErrUnknownScope struct {
+	Name string
+}
+
+func (e ErrUnknownScope) Error() string {
+	return fmt.Sprintf(`"%s" is not a registered scope`, e.Name)
+}
+
+// ErrFilterValue occurs when a query param given to a Filter has a value which cannot be used, and wraps the reason.
+type ErrFilterValue struct {
+	Param string
+	Err   error
+}
+
+func (e ErrFilterValue) Error() string {
+	return fmt.Sprintf(`filter param "%s" is invalid: %s`, e.Param, e.Err)
+}
+
+func (e ErrFilterValue) Unwrap() error {
+	return e.Err
+}
+
+// ErrTupleArity occurs when a tuple given to InTuple does not have one value for each field.
+type ErrTupleArity struct {
+	Want int
+	Got  int
+}
+
+func (e ErrTupleArity) Error() string {
+	return fmt.Sprintf("tuple has %d values, but there are %d fields", e.Got, e.Want)
+}
+
+// ErrStubRowArity occurs when a row given to RecordingExecutor.StubRows does not have one value for each column.
+type ErrStubRowArity struct {
+	Want int
+	Got  int
+}
+
+func (e ErrStubRowArity) Error() string {
+	return fmt.Sprintf("stubbed row has %d values, but there are %d columns", e.Got, e.Want)
+}
+
+// ErrDuplicateColumn occurs when more than one exported field of a struct maps to the same column name, such as when a
+// "db" tag gives a field the name of another field. Fields are the names of the first two conflicting fields.
+type ErrDuplicateColumn struct {
+	Name   string
+	Fields [2]string
+}
+
+func (e ErrDuplicateColumn) Error() string {
+	return fmt.Sprintf(`fields "%s" and "%s" both map to column "%s"`, e.Fields[0], e.Fields[1], e.Name)
+}
+
+// ErrUnassignableValue occurs when a value scanned from a column cannot be assigned or converted to the type of the
+// field it is mapped to.
+type ErrUnassignableValue struct {
+	Field     string
+	Type      reflect.Type
+	FieldType reflect.Type
+}
+
+func (e ErrUnassignableValue) Error() string {
+	return fmt.Sprintf(`value of type "%s" cannot be assigned to field "%s" of type "%s"`, e.Type, e.Field, e.FieldType)
+}
+
+// QueryError occurs when running a query fails, and wraps the error from the driver, along with the query which was run.
+// The args are kept for debugging, but are left out of the message, since they may contain secrets.
+type QueryError struct {
+	Query string
+	Args  []any
+	Err   error
+}
+
+func (e QueryError) Error() string {
+	return fmt.Sprintf("query failed: %s: %s (%d args)", e.Err, e.Query, len(e.Args))
+}
+
+func (e QueryError) Unwrap() error {
+	return e.Err
+}
+
+// ErrColumnMismatch occurs when mapping rows with strict columns, and the columns of the result do not line up with
+// the fields of the struct. Column is set for a column without a field, and Field is set for a field without a column.
+type ErrColumnMismatch struct {
+	Column string
+	Field  string
+}
+
+func (e ErrColumnMismatch) Error() string {
+	if e.Column != "" {
+		return fmt.Sprintf(`column "%s" has no matching field`, e.Column)
+	}
+	return fmt.Sprintf(`field "%s" has no matching column`, e.Field)
+}
+
+// ErrReadOnlyType occurs when building a query which writes to a type that is a View.
+type ErrReadOnlyType struct {
+	Type reflect.Type
+}
+
+func (e ErrReadOnlyType) Error() string {
+	return fmt.Sprintf(`"%s" is a view, and cannot be written to`, e.Type)
+}
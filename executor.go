@@ -0,0 +1,32 @@
+package qubr
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Executor is what queries are run against. This is satisfied by sql.DB, sql.Tx, and sql.Conn, so queries can be run
+// within a transaction, or on a single connection, the same way they are run against the database.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+var (
+	_ Executor = (*sql.DB)(nil)
+	_ Executor = (*sql.Tx)(nil)
+	_ Executor = (*sql.Conn)(nil)
+)
+
+// Preparer is what prepared statements are created with. Like Executor, this is satisfied by sql.DB, sql.Tx, and
+// sql.Conn.
+type Preparer interface {
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+var (
+	_ Preparer = (*sql.DB)(nil)
+	_ Preparer = (*sql.Tx)(nil)
+	_ Preparer = (*sql.Conn)(nil)
+)
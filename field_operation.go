@@ -2,7 +2,11 @@ package qubr
 
 import (
 	"fmt"
+	"iter"
+	"reflect"
+	"slices"
 	"strings"
+	"time"
 )
 
 // FieldOperation represents some field comparison operation utilizing an Operator.
@@ -15,18 +19,38 @@ type FieldOperation struct {
 	ValueRaw  any
 }
 
-func (f FieldOperation) queryData() (string, []any) {
+func (f FieldOperation) queryData() (string, []any, error) {
+	if tuple, ok := f.ValueRaw.(tupleValues); ok {
+		// Many fields on the left-hand side, which is rendered quite differently.
+		return tuple.queryData(f.Operator)
+	}
+	if between, ok := f.ValueRaw.(betweenValues); ok {
+		// Two values on the right-hand side, joined by AND.
+		return fmt.Sprintf(`"%s" %s ? AND ?`, f.FieldName, f.Operator), []any{between.low, between.high}, nil
+	}
+
 	var (
 		placeholders string
 		args         []any
 	)
 	{
 		argArr, isArr := f.ValueRaw.([]any)
-
-		if !isArr && f.Operator != OperatorIn && f.Operator != OperatorNotIn {
+		subquery, isSubquery := f.ValueRaw.(Subquery)
+
+		if isSubquery {
+			// Our "ValueRaw" is a whole query, which is placed inline, along with its args.
+			var err error
+			placeholders, args, err = subquery.queryData()
+			if err != nil {
+				return "", nil, err
+			}
+		} else if !isArr && f.Operator != OperatorIn && f.Operator != OperatorNotIn {
 			// Our "ValueRaw" is not an array of any, and it's not some kind of in operator.
 			placeholders = "?"
 			args = []any{f.ValueRaw}
+		} else if (f.Operator == OperatorIn || f.Operator == OperatorNotIn) && len(argArr) > inChunkSize {
+			// Too many values for a single IN, on some databases.
+			return f.chunkedQueryData(argArr)
 		} else {
 			// (?,?,?)
 			points := strings.Repeat("?, ", len(argArr))
@@ -35,7 +59,37 @@ func (f FieldOperation) queryData() (string, []any) {
 		}
 	}
 
-	return fmt.Sprintf(`"%s" %s %s`, f.FieldName, f.Operator, placeholders), args
+	return fmt.Sprintf(`"%s" %s %s`, f.FieldName, f.Operator, placeholders), args, nil
+}
+
+// inChunkSize is the most values placed within a single IN or NOT IN, since some databases limit the values it can have,
+// such as Oracle, which allows up to 1000.
+const inChunkSize = 500
+
+// chunkedQueryData will construct an IN or NOT IN with more than inChunkSize values as many of them, each with up to
+// inChunkSize of the values, within parentheses. Each IN is joined by OR, and each NOT IN by AND, so the result is the
+// same as a single one with every value.
+func (f FieldOperation) chunkedQueryData(values []any) (string, []any, error) {
+	connector := connectorOr
+	if f.Operator == OperatorNotIn {
+		connector = connectorAnd
+	}
+
+	var (
+		conditions []string
+		args       []any
+	)
+	for chunk := range slices.Chunk(values, inChunkSize) {
+		condition, chunkArgs, err := FieldOperation{f.Operator, f.FieldName, chunk}.queryData()
+		if err != nil {
+			return "", nil, err
+		}
+
+		conditions = append(conditions, condition)
+		args = append(args, chunkArgs...)
+	}
+
+	return fmt.Sprintf("(%s)", strings.Join(conditions, fmt.Sprintf(" %s ", connector))), args, nil
 }
 
 // Operator is a type representing one of the various comparison operators in ANSI SQL (ISO 9075).
@@ -50,6 +104,14 @@ const (
 	OperatorLessThanOrEqual
 	OperatorIn
 	OperatorNotIn
+	OperatorIsNotDistinctFrom
+	OperatorLike
+	OperatorILike
+	OperatorBetween
+	OperatorNotBetween
+
+	// OperatorNullSafeEqual is an alias of OperatorIsNotDistinctFrom, and is rendered the same way.
+	OperatorNullSafeEqual = OperatorIsNotDistinctFrom
 )
 
 func (o Operator) String() string {
@@ -71,18 +133,215 @@ func (o Operator) String() string {
 		s = "IN"
 	case OperatorNotIn:
 		s = "NOT IN"
+	case OperatorIsNotDistinctFrom:
+		s = "IS NOT DISTINCT FROM"
+	case OperatorLike:
+		s = "LIKE"
+	case OperatorILike:
+		s = "ILIKE"
+	case OperatorBetween:
+		s = "BETWEEN"
+	case OperatorNotBetween:
+		s = "NOT BETWEEN"
+	}
+	return s
+}
+
+// fieldOperationConnector is the boolean operator joining a fieldOperationEntry onto the entries before it.
+type fieldOperationConnector uint8
+
+const (
+	connectorAnd fieldOperationConnector = iota
+	connectorOr
+)
+
+func (c fieldOperationConnector) String() string {
+	var s string
+	switch c {
+	case connectorAnd:
+		s = "AND"
+	case connectorOr:
+		s = "OR"
 	}
 	return s
 }
 
+// fieldOperationEntry is a single condition of a fieldOperationTree. Only one of op, group, or raw is used.
+type fieldOperationEntry struct {
+	connector fieldOperationConnector // Ignored for the first entry.
+
+	op    FieldOperation
+	group *fieldOperationTree
+	raw   *rawCondition
+}
+
+// rawCondition is a condition of SQL which is placed into the where clause as it is, along with its args.
+type rawCondition struct {
+	expr string
+	args []any
+}
+
+// fieldOperationTree is the ordered list of conditions making up a where clause.
+// Each entry is joined to the previous using its connector, and nested groups are rendered within parentheses.
+// Only the groups are explicit, entries outside of a group are rendered flat, so SQL operator precedence still applies
+// to them, where AND binds before OR. For example, A AND B OR C is (A AND B) OR C.
 type fieldOperationTree struct {
-	op FieldOperation
+	entries []fieldOperationEntry
+}
+
+func (t fieldOperationTree) isEmpty() bool {
+	return len(t.entries) == 0
+}
+
+// mapFieldNames will return a copy of the tree, where the field names of every FieldOperation, including those within
+// groups, are replaced using fn.
+func (t fieldOperationTree) mapFieldNames(fn func(name string) string) fieldOperationTree {
+	entries := make([]fieldOperationEntry, len(t.entries))
+	for i, e := range t.entries {
+		e.op.FieldName = fn(e.op.FieldName)
+		if tuple, ok := e.op.ValueRaw.(tupleValues); ok {
+			fields := make([]string, len(tuple.fields))
+			for j, field := range tuple.fields {
+				fields[j] = fn(field)
+			}
+			e.op.ValueRaw = tupleValues{fields, tuple.tuples}
+		}
+		if e.group != nil {
+			group := e.group.mapFieldNames(fn)
+			e.group = &group
+		}
+
+		entries[i] = e
+	}
+
+	return fieldOperationTree{entries: entries}
+}
+
+// encodeTimes will return a copy of the tree, where the time.Time values of every FieldOperation, including those
+// within groups, are converted to the representation of f, so they compare correctly against columns stored that way.
+// The args of raw conditions are left as they are.
+func (t fieldOperationTree) encodeTimes(f TimeFormat) fieldOperationTree {
+	if f == TimeFormatNative {
+		return t
+	}
+
+	entries := make([]fieldOperationEntry, len(t.entries))
+	for i, e := range t.entries {
+		e.op.ValueRaw = encodeOperationValue(e.op.ValueRaw, f)
+		if e.group != nil {
+			group := e.group.encodeTimes(f)
+			e.group = &group
+		}
+
+		entries[i] = e
+	}
 
-	or  *fieldOperationTree
-	and *fieldOperationTree
+	return fieldOperationTree{entries: entries}
 }
 
-var emptyFieldOperationTree = fieldOperationTree{}
+// encodeOperationValue will convert any time.Time within the ValueRaw of a FieldOperation to the representation of f.
+func encodeOperationValue(v any, f TimeFormat) any {
+	switch v := v.(type) {
+	case []any:
+		encoded := make([]any, len(v))
+		for i, value := range v {
+			encoded[i] = f.encode(value)
+		}
+		return encoded
+	case tupleValues:
+		tuples := make([][]any, len(v.tuples))
+		for i, tuple := range v.tuples {
+			tuples[i] = encodeOperationValue(tuple, f).([]any)
+		}
+		return tupleValues{v.fields, tuples}
+	case betweenValues:
+		return betweenValues{f.encode(v.low), f.encode(v.high)}
+	default:
+		return f.encode(v)
+	}
+}
+
+// hasRaw will report whether any condition of the tree, or of its groups, is raw SQL, or compares against a Subquery
+// which has raw SQL.
+func (t fieldOperationTree) hasRaw() bool {
+	return slices.ContainsFunc(t.entries, func(e fieldOperationEntry) bool {
+		if subquery, ok := e.op.ValueRaw.(Subquery); ok && subquery.hasRaw() {
+			return true
+		}
+		return e.raw != nil || (e.group != nil && e.group.hasRaw())
+	})
+}
+
+// Group is a set of conditions which will be rendered within parentheses, as part of a where clause.
+// A Group is built within the function given to a builder's WhereGroup, AndGroup, or OrGroup methods.
+// Example:
+//
+//	Select[User]().
+//		Where(Equal("Active", true)).
+//		AndGroup(func(g *Group) {
+//			g.And(Equal("Role", "admin")).Or(Equal("Role", "owner"))
+//		})
+//
+// There is no XOR, since few databases support it, but matching when exactly one of two conditions is true can be built
+// from groups, where each condition is paired with the opposite of the other.
+// Example:
+//
+//	// "Carrots" > 0 XOR "Lettuce" > 0
+//	WhereGroup(func(g *Group) {
+//		g.AndGroup(func(g *Group) {
+//			g.And(GreaterThan("Carrots", 0)).And(LessThanOrEqual("Lettuce", 0))
+//		}).OrGroup(func(g *Group) {
+//			g.And(LessThanOrEqual("Carrots", 0)).And(GreaterThan("Lettuce", 0))
+//		})
+//	})
+type Group struct {
+	tree fieldOperationTree
+}
+
+// And will apply an AND to the conditions in the Group. If the Group is empty, this is simply the first condition.
+func (g *Group) And(op FieldOperation) *Group {
+	g.tree.entries = append(g.tree.entries, fieldOperationEntry{connector: connectorAnd, op: op})
+	return g
+}
+
+// Or will apply an OR to the conditions in the Group. If the Group is empty, this is simply the first condition.
+func (g *Group) Or(op FieldOperation) *Group {
+	g.tree.entries = append(g.tree.entries, fieldOperationEntry{connector: connectorOr, op: op})
+	return g
+}
+
+// AndGroup will apply an AND to the conditions in the Group, with a nested Group.
+func (g *Group) AndGroup(fn func(g *Group)) *Group {
+	g.tree.entries = append(g.tree.entries, fieldOperationEntry{connector: connectorAnd, group: buildGroup(fn)})
+	return g
+}
+
+// OrGroup will apply an OR to the conditions in the Group, with a nested Group.
+func (g *Group) OrGroup(fn func(g *Group)) *Group {
+	g.tree.entries = append(g.tree.entries, fieldOperationEntry{connector: connectorOr, group: buildGroup(fn)})
+	return g
+}
+
+// ActiveAt will construct a Group function, for use with a builder's WhereGroup, AndGroup, or OrGroup, which matches
+// rows where t is within the range of the startField and endField, including both ends.
+// Example:
+//
+//	Select[Offer]().WhereGroup(ActiveAt("StartsAt", "EndsAt", time.Now()))
+//
+// Equivalent SQL will be:
+//
+//	("startField" <= ? AND "endField" >= ?)
+func ActiveAt(startField, endField string, t time.Time) func(g *Group) {
+	return func(g *Group) {
+		g.And(LessThanOrEqual(startField, t)).And(GreaterThanOrEqual(endField, t))
+	}
+}
+
+func buildGroup(fn func(g *Group)) *fieldOperationTree {
+	g := &Group{}
+	fn(g)
+	return &g.tree
+}
 
 // Equal is a wrapper for constructing a FieldOperation with an OperatorEqual passed in.
 // Equivalent SQL will be:
@@ -100,6 +359,46 @@ func NotEqual(field string, v any) FieldOperation {
 	return FieldOperation{OperatorNotEqual, field, v}
 }
 
+// IsNotDistinctFrom is a wrapper for constructing a FieldOperation with an OperatorIsNotDistinctFrom passed in.
+// Unlike Equal, a NULL is equal to another NULL, and a NULL compared with any other value is false, rather than NULL.
+// This is the SQL standard form, supported by Postgres, SQLite, and SQL Server 2022. It is not portable, MySQL rejects
+// it, and only supports its own "<=>" operator, which is not rendered by qubr.
+// Equivalent SQL will be:
+//
+//	"field" IS NOT DISTINCT FROM ?
+func IsNotDistinctFrom(field string, v any) FieldOperation {
+	return FieldOperation{OperatorIsNotDistinctFrom, field, v}
+}
+
+// NullSafeEqual is an alias of IsNotDistinctFrom, for constructing a FieldOperation with an OperatorNullSafeEqual
+// passed in. qubr has no notion of the database dialect, so the SQL standard form is always rendered, and never the
+// MySQL "<=>" operator.
+// Equivalent SQL will be:
+//
+//	"field" IS NOT DISTINCT FROM ?
+func NullSafeEqual(field string, v any) FieldOperation {
+	return IsNotDistinctFrom(field, v)
+}
+
+// Like is a wrapper for constructing a FieldOperation with an OperatorLike passed in.
+// The pattern is passed as an arg, so any wildcards, such as "%", must be within the pattern itself.
+// Equivalent SQL will be:
+//
+//	"field" LIKE ?
+func Like(field string, pattern string) FieldOperation {
+	return FieldOperation{OperatorLike, field, pattern}
+}
+
+// ILike is a wrapper for constructing a FieldOperation with an OperatorILike passed in.
+// Like Like, but the match ignores case. ILIKE is supported by Postgres, but not by MySQL or SQLite, where LIKE already
+// ignores case for ASCII characters.
+// Equivalent SQL will be:
+//
+//	"field" ILIKE ?
+func ILike(field string, pattern string) FieldOperation {
+	return FieldOperation{OperatorILike, field, pattern}
+}
+
 // GreaterThan is a wrapper for constructing a FieldOperation with an OperatorGreaterThan passed in.
 // Equivalent SQL will be:
 //
@@ -132,6 +431,29 @@ func LessThanOrEqual(field string, v any) FieldOperation {
 	return FieldOperation{OperatorLessThanOrEqual, field, v}
 }
 
+// Between is a wrapper for constructing a FieldOperation with an OperatorBetween passed in.
+// The range includes both low and high.
+// Equivalent SQL will be:
+//
+//	"field" BETWEEN ? AND ?
+func Between(field string, low, high any) FieldOperation {
+	return FieldOperation{OperatorBetween, field, betweenValues{low, high}}
+}
+
+// NotBetween is a wrapper for constructing a FieldOperation with an OperatorNotBetween passed in.
+// Equivalent SQL will be:
+//
+//	"field" NOT BETWEEN ? AND ?
+func NotBetween(field string, low, high any) FieldOperation {
+	return FieldOperation{OperatorNotBetween, field, betweenValues{low, high}}
+}
+
+// betweenValues are the ends of the range of a FieldOperation constructed by Between or NotBetween.
+type betweenValues struct {
+	low  any
+	high any
+}
+
 // IsTrue is a wrapper for constructing a FieldOperation with an OperatorIsTrue passed in.
 // Since it's just a boolean comparison, we utilize Equal to do this.
 // Equivalent SQL will be:
@@ -151,88 +473,272 @@ func IsFalse(field string) FieldOperation {
 }
 
 // In is a wrapper for constructing a FieldOperation with an OperatorIn passed in.
+// If the only value given is a QueryBuilder, such as a SelectBuilder, it is used as a subquery instead of a value.
+// A QueryBuilder alongside other values is treated as any other value.
+// With more than 500 values, they are split across many INs, joined by OR, since some databases limit the values of a
+// single IN.
 // Equivalent SQL will be:
 //
 //	"field" IN (?, ...)
+//	("field" IN (?, ...) OR "field" IN (?, ...))
+//	"field" IN (SELECT ...)
 func In(field string, values ...any) FieldOperation {
-	return FieldOperation{OperatorIn, field, values}
+	return FieldOperation{OperatorIn, field, inValues(values)}
 }
 
 // NotIn is a wrapper for constructing a FieldOperation with an OperatorNotIn passed in.
+// Like In, if the only value given is a QueryBuilder, it is used as a subquery instead of a value.
+// With more than 500 values, they are split across many NOT INs, joined by AND.
 // Equivalent SQL will be:
 //
 //	"field" NOT IN (?, ...)
+//	("field" NOT IN (?, ...) AND "field" NOT IN (?, ...))
+//	"field" NOT IN (SELECT ...)
 func NotIn(field string, values ...any) FieldOperation {
-	return FieldOperation{OperatorNotIn, field, values}
+	return FieldOperation{OperatorNotIn, field, inValues(values)}
+}
+
+// InSeq is a wrapper for constructing a FieldOperation with an OperatorIn passed in, where the values come from seq.
+// This is useful when the values are streamed, rather than already being in a slice.
+// The sequence is fully drained when InSeq is called, so it must be finite, and is not read again when building.
+// Like In, more than 500 values are split across many INs, joined by OR.
+// Equivalent SQL will be:
+//
+//	"field" IN (?, ...)
+//	("field" IN (?, ...) OR "field" IN (?, ...))
+func InSeq[V any](field string, seq iter.Seq[V]) FieldOperation {
+	var values []any
+	for v := range seq {
+		values = append(values, v)
+	}
+	return FieldOperation{OperatorIn, field, values}
+}
+
+// Match is a wrapper for constructing either an In or an Equal, depending on the type of v.
+// If v is a slice or array, each of its elements is used as a value of an In. Otherwise, v is compared using Equal.
+// Slices and arrays of bytes, such as []byte, json.RawMessage, net.IP, or a [16]byte UUID, are a single value, so are
+// compared using Equal. Since the operator changes with the type of v, prefer Equal or In when the type is known.
+// Like In, an empty slice results in an empty IN, which most databases reject.
+// Equivalent SQL will be:
+//
+//	"field" = ?
+//	"field" IN (?, ...)
+func Match(field string, v any) FieldOperation {
+	value := reflect.ValueOf(v)
+	if kind := value.Kind(); kind != reflect.Slice && kind != reflect.Array {
+		return Equal(field, v)
+	}
+	if value.Type().Elem().Kind() == reflect.Uint8 {
+		return Equal(field, v)
+	}
+
+	values := make([]any, value.Len())
+	for i := range values {
+		values[i] = value.Index(i).Interface()
+	}
+	return FieldOperation{OperatorIn, field, values}
+}
+
+// InTuple is a wrapper for constructing a FieldOperation with an OperatorIn passed in, comparing many fields at once
+// against a list of tuples. Every tuple must have one value for each of the fields, in the same order.
+// This is useful for looking up rows by a composite key. Row values like this are supported by Postgres, MySQL, and
+// SQLite, but not by SQL Server.
+// Equivalent SQL will be:
+//
+//	("field1", "field2") IN ((?, ?), ...)
+func InTuple(fields []string, tuples ...[]any) FieldOperation {
+	return FieldOperation{Operator: OperatorIn, ValueRaw: tupleValues{fields, tuples}}
+}
+
+// tupleValues are the fields and values of a FieldOperation constructed by InTuple.
+type tupleValues struct {
+	fields []string
+	tuples [][]any
+}
+
+func (t tupleValues) queryData(op Operator) (string, []any, error) {
+	if len(t.fields) == 0 {
+		return "", nil, ErrTupleArity{0, 0}
+	}
+
+	// ("X", "Y")
+	sb := strings.Builder{}
+	for _, field := range t.fields {
+		sb.WriteString(fmt.Sprintf(`"%s", `, field))
+	}
+	fields := fmt.Sprintf("(%s)", strings.TrimSuffix(sb.String(), ", "))
+
+	// ((?, ?), (?, ?))
+	var args []any
+	tuple := fmt.Sprintf("(%s)", strings.TrimSuffix(strings.Repeat("?, ", len(t.fields)), ", "))
+	tuples := make([]string, len(t.tuples))
+	for i, values := range t.tuples {
+		if len(values) != len(t.fields) {
+			return "", nil, ErrTupleArity{len(t.fields), len(values)}
+		}
+
+		tuples[i] = tuple
+		args = append(args, values...)
+	}
+
+	return fmt.Sprintf("%s %s (%s)", fields, op, strings.Join(tuples, ", ")), args, nil
+}
+
+// inValues will determine if the values for an in operator are a subquery, or a list of values.
+func inValues(values []any) any {
+	if len(values) != 1 {
+		return values
+	}
+
+	switch v := values[0].(type) {
+	case Subquery:
+		return v
+	case QueryBuilder:
+		return Subquery{builder: v}
+	default:
+		return values
+	}
 }
 
 // buildQuery will construct a where clause for SQL queries.
-func (t fieldOperationTree) buildQuery() (string, []any) {
-	if t == emptyFieldOperationTree {
-		return "", nil
+func (t fieldOperationTree) buildQuery() (string, []any, error) {
+	if t.isEmpty() {
+		return "", nil, nil
+	}
+
+	query, args, err := t.buildConditions()
+	if err != nil {
+		return "", nil, err
 	}
 
+	return " WHERE " + query, args, nil
+}
+
+// buildConditions will construct the conditions of the tree, without the leading WHERE.
+func (t fieldOperationTree) buildConditions() (string, []any, error) {
 	var args []any
 
-	// The WHERE clause is present. Includes building the AND/OR nodes.
 	// Since this is not obviously sized, we are going to use a strings.Builder for efficiency.
 	sb := strings.Builder{}
 
-	sb.WriteString(" WHERE ")
-
-	query, data := t.op.queryData()
-	sb.WriteString(query)
-	args = append(args, data...)
-
-	// Walk down the tree for each "and" and "or" branch.
-	// Write the op node out once discovered. Otherwise, we are done.
-
-	next := t
-	for {
-		if next.and == nil && next.or == nil {
-			// Nothing left to be written.
-			break
-		} else if next.and != nil {
-			next = *next.and
-			sb.WriteString(" AND ")
-		} else if next.or != nil {
-			next = *next.or
-			sb.WriteString(" OR ")
+	for i, entry := range t.entries {
+		if i > 0 {
+			sb.WriteString(fmt.Sprintf(" %s ", entry.connector))
 		}
 
-		// Both branches will append data the same way.
-		query, data := next.op.queryData()
+		query, data, err := entry.queryData()
+		if err != nil {
+			return "", nil, err
+		}
 		sb.WriteString(query)
 		args = append(args, data...)
 	}
 
-	return sb.String(), args
+	return sb.String(), args, nil
 }
 
-func appendToFieldOperationTree(opTree *fieldOperationTree, assign func(next *fieldOperationTree)) error {
-	if opTree == nil || *opTree == emptyFieldOperationTree {
-		return ErrMissingWhereClause
+func (e fieldOperationEntry) queryData() (string, []any, error) {
+	if e.group != nil {
+		query, args, err := e.group.buildConditions()
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("(%s)", query), args, nil
 	}
 
-	// Starting from our top node, "where", we walk down either the "and" or "or" branch.
-	// Once we reach the bottom, we assign.
+	if e.raw != nil {
+		// The raw condition may have its own AND/OR operators, so we make sure it is kept together.
+		return fmt.Sprintf("(%s)", e.raw.expr), e.raw.args, nil
+	}
 
-	next := opTree
-	for {
-		if next.and != nil {
-			next = next.and
-			continue
+	return e.op.queryData()
+}
+
+func newRawCondition(expr string, args []any) (*rawCondition, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, ErrEmptyRawCondition
+	}
+	return &rawCondition{expr, args}, nil
+}
+
+// newFieldOperationGroup will build a Group using fn, checking that neither it nor any nested Group are empty.
+// An empty Group cannot be rendered as valid SQL.
+func newFieldOperationGroup(fn func(g *Group)) (*fieldOperationTree, error) {
+	group := buildGroup(fn)
+
+	var checkEmpty func(t *fieldOperationTree) error
+	checkEmpty = func(t *fieldOperationTree) error {
+		if t.isEmpty() {
+			return ErrEmptyGroup
+		}
+		for _, entry := range t.entries {
+			if entry.group == nil {
+				continue
+			}
+			if err := checkEmpty(entry.group); err != nil {
+				return err
+			}
 		}
-		if next.or != nil {
-			next = next.or
+		return nil
+	}
+
+	if err := checkEmpty(group); err != nil {
+		return nil, err
+	}
+
+	return group, nil
+}
+
+// newExampleFieldOperationTree will construct a tree of Equal operations, joined by AND, for each exported field of
+// the example which is not the zero value for its type.
+// If every exported field is the zero value, then ErrEmptyExample is returned, as the tree would match all rows.
+func newExampleFieldOperationTree[T any](example T) (fieldOperationTree, error) {
+	exampleType := reflect.TypeFor[T]()
+	if err := checkStructType(exampleType); err != nil {
+		return fieldOperationTree{}, err
+	}
+	exampleValue := reflect.ValueOf(example)
+
+	var tree fieldOperationTree
+	for i := range exampleType.NumField() {
+		f := exampleType.Field(i)
+		v := exampleValue.Field(i)
+		if !f.IsExported() || v.IsZero() {
 			continue
 		}
 
-		// We reached the bottom.
-		assign(next)
+		tree.entries = append(tree.entries, fieldOperationEntry{
+			connector: connectorAnd,
+			op:        Equal(structFieldName(f), v.Interface()),
+		})
+	}
+
+	if tree.isEmpty() {
+		return fieldOperationTree{}, ErrEmptyExample
+	}
 
-		break
+	return tree, nil
+}
+
+// newIDsFieldOperationTree will construct a tree of an In operation on the column, for each of the ids.
+// If there are no ids, then ErrNoIDs is returned, as the IN would be invalid.
+func newIDsFieldOperationTree(column string, ids []any) (fieldOperationTree, error) {
+	if len(ids) == 0 {
+		return fieldOperationTree{}, ErrNoIDs
 	}
 
+	// Not using In, since a single id which is a QueryBuilder would become a subquery.
+	return fieldOperationTree{entries: []fieldOperationEntry{{op: FieldOperation{OperatorIn, column, ids}}}}, nil
+}
+
+func appendToFieldOperationTree(opTree *fieldOperationTree, entry fieldOperationEntry) error {
+	if opTree == nil || opTree.isEmpty() {
+		return ErrMissingWhereClause
+	}
+
+	// Builders are passed around by value, so the entries may be shared with another builder.
+	// Clipping the capacity forces append to copy, rather than writing into a shared array.
+	opTree.entries = append(slices.Clip(opTree.entries), entry)
+
 	return nil
 }
@@ -1,15 +1,16 @@
 package qubr
 
 import (
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
+	"slices"
+	"strings"
 	"testing"
 )
 
 func Test_fieldOperationTree_BuildQuery(t1 *testing.T) {
 	type fields struct {
-		op  FieldOperation
-		or  *fieldOperationTree
-		and *fieldOperationTree
+		entries []fieldOperationEntry
 	}
 	tests := []struct {
 		name      string
@@ -17,10 +18,18 @@ func Test_fieldOperationTree_BuildQuery(t1 *testing.T) {
 		wantQuery string
 		wantArgs  []any
 	}{
+		{
+			name:      "no entries",
+			fields:    fields{},
+			wantQuery: "",
+			wantArgs:  nil,
+		},
 		{
 			name: "simple field equality",
 			fields: fields{
-				op: Equal("Name", "captain spud"),
+				entries: []fieldOperationEntry{
+					{op: Equal("Name", "captain spud")},
+				},
 			},
 			wantQuery: ` WHERE "Name" = ?`,
 			wantArgs:  []any{"captain spud"},
@@ -28,9 +37,9 @@ func Test_fieldOperationTree_BuildQuery(t1 *testing.T) {
 		{
 			name: "chaining multiple equals",
 			fields: fields{
-				op: Equal("Name", "captain spud"),
-				and: &fieldOperationTree{
-					op: Equal("Age", 291),
+				entries: []fieldOperationEntry{
+					{op: Equal("Name", "captain spud")},
+					{connector: connectorAnd, op: Equal("Age", 291)},
 				},
 			},
 			wantQuery: ` WHERE "Name" = ? AND "Age" = ?`,
@@ -39,28 +48,220 @@ func Test_fieldOperationTree_BuildQuery(t1 *testing.T) {
 		{
 			name: "complex tree with many operations",
 			fields: fields{
-				op: In("FavoriteFood", "kale", "broccoli", "bok choi", "lettuce", "cranberries"),
-				and: &fieldOperationTree{
-					op: GreaterThanOrEqual("Age", 100),
-					or: &fieldOperationTree{
-						op: Equal("Deets", 2),
-					},
+				entries: []fieldOperationEntry{
+					{op: In("FavoriteFood", "kale", "broccoli", "bok choi", "lettuce", "cranberries")},
+					{connector: connectorAnd, op: GreaterThanOrEqual("Age", 100)},
+					{connector: connectorOr, op: Equal("Deets", 2)},
 				},
 			},
 			wantQuery: ` WHERE "FavoriteFood" IN (?, ?, ?, ?, ?) AND "Age" >= ? OR "Deets" = ?`,
 			wantArgs:  []any{"kale", "broccoli", "bok choi", "lettuce", "cranberries", 100, 2},
 		},
+		{
+			name: "explicitly grouped operations",
+			fields: fields{
+				entries: []fieldOperationEntry{
+					{op: Equal("Name", "captain spud")},
+					{
+						connector: connectorAnd,
+						group: &fieldOperationTree{
+							entries: []fieldOperationEntry{
+								{op: LessThan("Age", 10)},
+								{connector: connectorOr, op: GreaterThan("Age", 200)},
+							},
+						},
+					},
+					{connector: connectorOr, op: Equal("Deets", 2)},
+				},
+			},
+			wantQuery: ` WHERE "Name" = ? AND ("Age" < ? OR "Age" > ?) OR "Deets" = ?`,
+			wantArgs:  []any{"captain spud", 10, 200, 2},
+		},
 	}
 	for _, tt := range tests {
 		t1.Run(tt.name, func(t1 *testing.T) {
 			t := fieldOperationTree{
-				op:  tt.fields.op,
-				or:  tt.fields.or,
-				and: tt.fields.and,
+				entries: tt.fields.entries,
 			}
-			gotQuery, gotArgs := t.buildQuery()
+			gotQuery, gotArgs, err := t.buildQuery()
+			assert.NoError(t1, err)
 			assert.Equalf(t1, tt.wantQuery, gotQuery, "buildQuery()")
 			assert.Equalf(t1, tt.wantArgs, gotArgs, "buildQuery()")
 		})
 	}
 }
+
+func Test_appendToFieldOperationTree(t *testing.T) {
+	base := fieldOperationTree{entries: []fieldOperationEntry{{op: Equal("Name", "captain spud")}}}
+	base.entries = append(base.entries, fieldOperationEntry{connector: connectorAnd, op: Equal("Age", 291)})
+
+	// Branching off of the same tree should never affect the other branch.
+	left, right := base, base
+	assert.NoError(t, appendToFieldOperationTree(&left, fieldOperationEntry{connector: connectorOr, op: Equal("Deets", 1)}))
+	assert.NoError(t, appendToFieldOperationTree(&right, fieldOperationEntry{connector: connectorOr, op: Equal("Deets", 2)}))
+
+	assert.Equal(t, Equal("Deets", 1), left.entries[2].op)
+	assert.Equal(t, Equal("Deets", 2), right.entries[2].op)
+	assert.Len(t, base.entries, 2)
+
+	assert.ErrorIs(t, ErrMissingWhereClause, appendToFieldOperationTree(&fieldOperationTree{}, fieldOperationEntry{}))
+}
+
+func TestFieldOperation_queryData(t *testing.T) {
+	tests := []struct {
+		name      string
+		op        FieldOperation
+		wantQuery string
+		wantArgs  []any
+		wantErr   error
+	}{
+		{
+			name:      "in sequence",
+			op:        InSeq("Age", slices.Values([]int64{1, 2, 3})),
+			wantQuery: `"Age" IN (?, ?, ?)`,
+			wantArgs:  []any{int64(1), int64(2), int64(3)},
+		},
+		{
+			name:      "match scalar",
+			op:        Match("Age", int64(2)),
+			wantQuery: `"Age" = ?`,
+			wantArgs:  []any{int64(2)},
+		},
+		{
+			name:      "match slice",
+			op:        Match("Age", []int64{1, 2, 3}),
+			wantQuery: `"Age" IN (?, ?, ?)`,
+			wantArgs:  []any{int64(1), int64(2), int64(3)},
+		},
+		{
+			name:      "match array",
+			op:        Match("Name", [2]string{"ollie", "oliver"}),
+			wantQuery: `"Name" IN (?, ?)`,
+			wantArgs:  []any{"ollie", "oliver"},
+		},
+		{
+			name:      "match bytes",
+			op:        Match("Secret", []byte("carrots")),
+			wantQuery: `"Secret" = ?`,
+			wantArgs:  []any{[]byte("carrots")},
+		},
+		{
+			name:      "match named byte slice",
+			op:        Match("Deets", json.RawMessage(`{"carrots":1}`)),
+			wantQuery: `"Deets" = ?`,
+			wantArgs:  []any{json.RawMessage(`{"carrots":1}`)},
+		},
+		{
+			name:      "match byte array",
+			op:        Match("ID", [4]byte{1, 2, 3, 4}),
+			wantQuery: `"ID" = ?`,
+			wantArgs:  []any{[4]byte{1, 2, 3, 4}},
+		},
+		{
+			name:      "between",
+			op:        Between("Age", 1, 3),
+			wantQuery: `"Age" BETWEEN ? AND ?`,
+			wantArgs:  []any{1, 3},
+		},
+		{
+			name:      "not between",
+			op:        NotBetween("Age", 1, 3),
+			wantQuery: `"Age" NOT BETWEEN ? AND ?`,
+			wantArgs:  []any{1, 3},
+		},
+		{
+			name:      "like",
+			op:        Like("Name", "%oll%"),
+			wantQuery: `"Name" LIKE ?`,
+			wantArgs:  []any{"%oll%"},
+		},
+		{
+			name:      "ilike",
+			op:        ILike("Name", "%OLL%"),
+			wantQuery: `"Name" ILIKE ?`,
+			wantArgs:  []any{"%OLL%"},
+		},
+		{
+			name:      "in sequence with too many values for one in",
+			op:        InSeq("Age", slices.Values(make([]int64, 1001))),
+			wantQuery: `("Age" IN (` + placeholders(500) + `) OR "Age" IN (` + placeholders(500) + `) OR "Age" IN (?))`,
+			wantArgs:  slices.Repeat([]any{int64(0)}, 1001),
+		},
+		{
+			name:      "not in with too many values for one not in",
+			op:        NotIn("Age", slices.Repeat([]any{1}, 501)...),
+			wantQuery: `("Age" NOT IN (` + placeholders(500) + `) AND "Age" NOT IN (?))`,
+			wantArgs:  slices.Repeat([]any{1}, 501),
+		},
+		{
+			name:      "in tuple",
+			op:        InTuple([]string{"Farm", "Row"}, []any{"ollie's", 1}, []any{"oliver's", 2}),
+			wantQuery: `("Farm", "Row") IN ((?, ?), (?, ?))`,
+			wantArgs:  []any{"ollie's", 1, "oliver's", 2},
+		},
+		{
+			name:    "in tuple with mismatched arity",
+			op:      InTuple([]string{"Farm", "Row"}, []any{"ollie's", 1}, []any{"oliver's"}),
+			wantErr: ErrTupleArity{2, 1},
+		},
+		{
+			name:    "in tuple without fields",
+			op:      InTuple(nil, []any{"ollie's"}),
+			wantErr: ErrTupleArity{0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotQuery, gotArgs, err := tt.op.queryData()
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantQuery, gotQuery)
+			assert.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
+
+func TestGroup_exclusiveOr(t *testing.T) {
+	type bunny struct {
+		Name    string
+		Carrots int64
+		Lettuce int64
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "Carrots" INT, "Lettuce" INT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 1, 0)`,
+		`INSERT INTO "bunny" VALUES('oliver', 0, 2)`,
+		`INSERT INTO "bunny" VALUES('king ollie', 3, 4)`,
+		`INSERT INTO "bunny" VALUES('hungry ollie', 0, 0)`,
+	)
+
+	// The XOR recipe from the Group docs, which matches when exactly one side is true.
+	builder := Select[bunny]().
+		WithFields("Name").
+		WhereGroup(func(g *Group) {
+			g.AndGroup(func(g *Group) {
+				g.And(GreaterThan("Carrots", 0)).And(LessThanOrEqual("Lettuce", 0))
+			}).OrGroup(func(g *Group) {
+				g.And(LessThanOrEqual("Carrots", 0)).And(GreaterThan("Lettuce", 0))
+			})
+		})
+
+	query, args, err := builder.BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`SELECT "Name" FROM "bunny" WHERE (("Carrots" > ? AND "Lettuce" <= ?) OR ("Carrots" <= ? AND "Lettuce" > ?));`,
+		query,
+	)
+	assert.Equal(t, []any{0, 0, 0, 0}, args)
+
+	bunnies, err := builder.Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{Name: "ollie"}, {Name: "oliver"}}, bunnies)
+}
+
+// placeholders will construct n comma separated placeholders, as they are rendered within an IN.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
@@ -0,0 +1,80 @@
+package qubr
+
+import (
+	"maps"
+	"net/url"
+	"slices"
+)
+
+// Filter is an allowlist of the query params which can be used to filter a select, such as for a list endpoint.
+// Each param is mapped to the field it filters, and how. Params which are not in the Filter are ignored, so only the
+// fields allowed can ever be filtered.
+// Example:
+//
+//	filter := Filter{
+//		"name":    {Field: "Name", Operator: OperatorIn},
+//		"min_age": {Field: "Age", Operator: OperatorGreaterThanOrEqual, Parse: func(v string) (any, error) {
+//			return strconv.Atoi(v)
+//		}},
+//	}
+//
+//	// GET /bunnies?name=ollie&name=oliver&min_age=2
+//	bunnies, err := Select[Bunny]().WhereFilter(filter, r.URL.Query()).QueryContext(ctx, db)
+type Filter map[string]FilterParam
+
+// FilterParam is how a single query param of a Filter is turned into a FieldOperation.
+// With OperatorIn or OperatorNotIn, every value of the param is used. With OperatorBetween or OperatorNotBetween, the
+// param must have two values, the low and then the high end of the range. Otherwise, the param must only have one value.
+// Each value is given to Parse, which can reject bad values with an error. If Parse is nil, the value is used as it is.
+type FilterParam struct {
+	Field    string
+	Operator Operator
+	Parse    func(value string) (any, error)
+}
+
+// Conditions will convert the values into a FieldOperation for each param in the Filter, ordered by the param name.
+// Params in values which are not in the Filter are ignored.
+func (f Filter) Conditions(values url.Values) ([]FieldOperation, error) {
+	var ops []FieldOperation
+	for _, name := range slices.Sorted(maps.Keys(f)) {
+		raw, ok := values[name]
+		if !ok || len(raw) == 0 {
+			continue
+		}
+
+		param := f[name]
+		isIn := param.Operator == OperatorIn || param.Operator == OperatorNotIn
+		isBetween := param.Operator == OperatorBetween || param.Operator == OperatorNotBetween
+		switch {
+		case isBetween && len(raw) != 2:
+			return nil, ErrFilterValue{name, ErrFilterRangeValues}
+		case !isIn && !isBetween && len(raw) > 1:
+			return nil, ErrFilterValue{name, ErrFilterMultipleValues}
+		}
+
+		parsed := make([]any, len(raw))
+		for i, v := range raw {
+			if param.Parse == nil {
+				parsed[i] = v
+				continue
+			}
+
+			var err error
+			if parsed[i], err = param.Parse(v); err != nil {
+				return nil, ErrFilterValue{name, err}
+			}
+		}
+
+		if isIn {
+			ops = append(ops, FieldOperation{param.Operator, param.Field, parsed})
+			continue
+		}
+		if isBetween {
+			ops = append(ops, FieldOperation{param.Operator, param.Field, betweenValues{parsed[0], parsed[1]}})
+			continue
+		}
+		ops = append(ops, FieldOperation{param.Operator, param.Field, parsed[0]})
+	}
+
+	return ops, nil
+}
@@ -0,0 +1,104 @@
+package qubr
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+var bunnyFilter = Filter{
+	"name": {Field: "Name", Operator: OperatorIn},
+	"min_age": {Field: "Age", Operator: OperatorGreaterThanOrEqual, Parse: func(v string) (any, error) {
+		return strconv.Atoi(v)
+	}},
+	"ear_length": {Field: "EarLength", Operator: OperatorNotBetween, Parse: func(v string) (any, error) {
+		return strconv.ParseFloat(v, 64)
+	}},
+}
+
+func TestFilter_Conditions(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    []FieldOperation
+		wantErr error
+	}{
+		{
+			name:  "multiple values to in",
+			query: "name=ollie&name=oliver&min_age=2",
+			want: []FieldOperation{
+				GreaterThanOrEqual("Age", 2),
+				In("Name", "ollie", "oliver"),
+			},
+		},
+		{
+			name:  "unknown params are ignored",
+			query: "Secret=1&name=ollie",
+			want:  []FieldOperation{In("Name", "ollie")},
+		},
+		{
+			name:  "no params",
+			query: "",
+		},
+		{
+			name:    "bad value",
+			query:   "min_age=old",
+			wantErr: strconv.ErrSyntax,
+		},
+		{
+			name:  "two values to between",
+			query: "ear_length=10&ear_length=15.5",
+			want:  []FieldOperation{NotBetween("EarLength", 10.0, 15.5)},
+		},
+		{
+			name:    "one value to between",
+			query:   "ear_length=10",
+			wantErr: ErrFilterValue{"ear_length", ErrFilterRangeValues},
+		},
+		{
+			name:    "multiple values without in",
+			query:   "min_age=1&min_age=2",
+			wantErr: ErrFilterValue{"min_age", ErrFilterMultipleValues},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			assert.NoError(t, err)
+
+			got, err := bunnyFilter.Conditions(values)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSelectWhereFilter(t *testing.T) {
+	type bunny struct {
+		Name string
+		Age  int64
+	}
+
+	values := url.Values{"name": {"ollie", "oliver"}, "min_age": {"2"}}
+
+	query, args, err := Select[bunny]().
+		WhereFilter(bunnyFilter, values).
+		BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Name", "Age" FROM "bunny" WHERE "Age" >= ? AND "Name" IN (?, ?);`, query)
+	assert.Equal(t, []any{2, "ollie", "oliver"}, args)
+
+	query, args, err = Select[bunny]().
+		Where(NotEqual("Name", "king ollie")).
+		WhereFilter(bunnyFilter, url.Values{}).
+		BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Name", "Age" FROM "bunny" WHERE "Name" <> ?;`, query)
+	assert.Equal(t, []any{"king ollie"}, args)
+
+	_, _, err = Select[bunny]().
+		WhereFilter(bunnyFilter, url.Values{"min_age": {"old"}}).
+		BuildQuery()
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+}
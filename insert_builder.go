@@ -4,8 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"iter"
 	"reflect"
+	"slices"
 	"strings"
+	"time"
 )
 
 // InsertBuilder is a QueryBuilder for building SQL INSERT queries.
@@ -22,6 +25,15 @@ type InsertBuilder[T any] struct {
 	into tableName
 
 	literalValues []T
+	seqValues     iter.Seq[T]
+
+	timeFormat TimeFormat
+
+	comment string
+
+	timeout time.Duration
+
+	strictExportedFields bool
 
 	err error
 }
@@ -52,8 +64,51 @@ func (b InsertBuilder[T]) Into(tableName string) InsertBuilder[T] {
 
 // Values will represent the values to be inserted into your table. Each struct given being a row, and its fields
 // being the columns.
+// A field with the "default" option in its "db" tag, such as `db:"created_at,default=CURRENT_TIMESTAMP"`, is inserted
+// as that expression when it is the zero value, rather than as a placeholder. The expression is placed into the query
+// as it is, and cannot contain a comma, since options are comma separated.
 func (b InsertBuilder[T]) Values(t ...T) InsertBuilder[T] {
 	b.literalValues = t
+	b.seqValues = nil
+	return b
+}
+
+// FromSeq will represent the values to be inserted into your table as a sequence, which is only read when running
+// InsertBuilder.ExecBatched, so that every row never needs to be in memory at once. Like InsertBuilder.Values, each
+// struct is a row. Since the sequence is not read until then, InsertBuilder.BuildQuery does not include these values.
+func (b InsertBuilder[T]) FromSeq(seq iter.Seq[T]) InsertBuilder[T] {
+	b.literalValues = nil
+	b.seqValues = seq
+	return b
+}
+
+// StoreTimeAs will set the TimeFormat which time.Time fields are stored as. By default, this is TimeFormatNative.
+func (b InsertBuilder[T]) StoreTimeAs(f TimeFormat) InsertBuilder[T] {
+	b.timeFormat = f
+	return b
+}
+
+// WithComment will place a comment at the start of the query, which is useful for tracing queries back to the code
+// which ran them, such as in slow query logs. Anything which would close the comment early is removed.
+// Equivalent SQL will be:
+//
+//	/* comment */ ...
+func (b InsertBuilder[T]) WithComment(comment string) InsertBuilder[T] {
+	b.comment = comment
+	return b
+}
+
+// Timeout will set a deadline for running the query, when it is run without a context, such as by InsertBuilder.Exec.
+// If a context is given, such as to InsertBuilder.ExecContext, then the timeout is not used.
+func (b InsertBuilder[T]) Timeout(d time.Duration) InsertBuilder[T] {
+	b.timeout = d
+	return b
+}
+
+// StrictExportedFields will cause InsertBuilder.BuildQuery to return an ErrUnexportedField if T has any unexported fields.
+// By default, unexported fields are silently skipped.
+func (b InsertBuilder[T]) StrictExportedFields() InsertBuilder[T] {
+	b.strictExportedFields = true
 	return b
 }
 
@@ -65,8 +120,8 @@ func (b InsertBuilder[T]) Values(t ...T) InsertBuilder[T] {
 //
 //	INSERT INTO "table" VALUES (?, ?, ?);
 func (b InsertBuilder[T]) BuildQuery() (query string, args []any, err error) {
-	if b.err != nil {
-		return "", nil, b.err
+	if err := b.check(); err != nil {
+		return "", nil, err
 	}
 
 	tableName := b.into.String()
@@ -79,30 +134,38 @@ func (b InsertBuilder[T]) BuildQuery() (query string, args []any, err error) {
 			return "", nil, ErrNoInsertValues
 		}
 
-		// Determine the number of settable fields on the struct.
-		insertType := reflect.TypeFor[T]()
-		var numExported int
-		for i := range insertType.NumField() {
-			f := insertType.Field(i)
-			if !f.IsExported() {
-				continue
-			}
-
-			numExported++
-		}
+		// Determine the settable fields on the struct.
+		fields := exportedFields(reflect.TypeFor[T]())
 
 		sb := strings.Builder{}
 		sb.WriteString(" VALUES ")
 		for i, v := range b.literalValues {
+			if err := validate(v); err != nil {
+				return "", nil, err
+			}
+
 			insertValue := reflect.ValueOf(v)
 
 			// (?,?)
-			placeholders := strings.TrimSuffix(strings.Repeat("?, ", numExported), ", ") // Remove trailing comma.
-			sb.WriteString(fmt.Sprintf("(%s)", placeholders))
+			placeholders := make([]string, len(fields))
+			for j, f := range fields {
+				fieldValue := insertValue.FieldByIndex(f.Index)
+
+				// A zero value with a default is left for the database to fill in, using the expression as it is.
+				if expr, ok := structFieldOption(f, "default"); ok && fieldValue.IsZero() {
+					placeholders[j] = expr
+					continue
+				}
+
+				arg, err := encodeFieldValue(f, fieldValue.Interface(), b.timeFormat)
+				if err != nil {
+					return "", nil, err
+				}
 
-			for i := range numExported {
-				args = append(args, insertValue.Field(i).Interface())
+				placeholders[j] = "?"
+				args = append(args, arg)
 			}
+			sb.WriteString(fmt.Sprintf("(%s)", strings.Join(placeholders, ", ")))
 
 			if i < len(b.literalValues)-1 {
 				sb.WriteString(", ")
@@ -112,21 +175,194 @@ func (b InsertBuilder[T]) BuildQuery() (query string, args []any, err error) {
 		values = sb.String()
 	}
 
-	return fmt.Sprintf("INSERT INTO %s%s;", tableName, values), args, nil
+	return fmt.Sprintf("%sINSERT INTO %s%s;", commentPrefix(b.comment), tableName, values), args, nil
+}
+
+// check will return the first issue with the construction of the InsertBuilder, or with T, which would stop any query
+// from being built, regardless of the values being inserted.
+func (b InsertBuilder[T]) check() error {
+	if b.err != nil {
+		return b.err
+	}
+	if err := checkStructType(reflect.TypeFor[T]()); err != nil {
+		return err
+	}
+	if err := checkWritable[T](); err != nil {
+		return err
+	}
+	if b.strictExportedFields {
+		if err := checkExportedFields(reflect.TypeFor[T]()); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Exec wraps InsertBuilder.ExecContext, which will execute the insert query represented by the InsertBuilder.
-func (b InsertBuilder[T]) Exec(db *sql.DB) (sql.Result, error) {
-	return b.ExecContext(context.Background(), db)
+func (b InsertBuilder[T]) Exec(db Executor) (sql.Result, error) {
+	ctx, cancel := timeoutContext(b.timeout)
+	defer cancel()
+
+	return b.ExecContext(ctx, db)
+}
+
+// ExecBatched will insert the values of the InsertBuilder, in batches of up to batchSize rows, with one query for each
+// batch. The values are read as each batch is inserted, so with InsertBuilder.FromSeq, only a single batch is ever kept
+// in memory. The total rows affected by every batch is returned. If there are no values, then ErrNoInsertValues is
+// returned, like with InsertBuilder.Exec.
+// If db is a sql.DB, then every batch is inserted within a single transaction, so if a batch fails, none of the batches
+// are inserted. Any other Executor, such as a sql.Tx, is used as it is, so its batches before the failed batch remain.
+func (b InsertBuilder[T]) ExecBatched(ctx context.Context, db Executor, batchSize int) (int64, error) {
+	if batchSize < 1 {
+		return 0, ErrInvalidBatchSize
+	}
+	// Checked before reading any values, so that an empty sequence does not hide the error behind ErrNoInsertValues.
+	if err := b.check(); err != nil {
+		return 0, err
+	}
+
+	sqlDB, ok := db.(*sql.DB)
+	if !ok {
+		return b.execBatched(ctx, db, batchSize)
+	}
+
+	var rowsAffected int64
+	err := WithTransaction(ctx, sqlDB, func(tx *sql.Tx) error {
+		var err error
+		rowsAffected, err = b.execBatched(ctx, tx, batchSize)
+		return err
+	})
+	if err != nil {
+		// The transaction was rolled back, so nothing was inserted.
+		return 0, err
+	}
+
+	return rowsAffected, nil
+}
+
+func (b InsertBuilder[T]) execBatched(ctx context.Context, db Executor, batchSize int) (int64, error) {
+	seq := b.seqValues
+	if seq == nil {
+		seq = slices.Values(b.literalValues)
+	}
+
+	var rowsAffected int64
+	empty := true
+	execBatch := func(batch []T) error {
+		result, err := b.Values(batch...).ExecContext(ctx, db)
+		if err != nil {
+			return err
+		}
+
+		n, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		rowsAffected += n
+		return nil
+	}
+
+	batch := make([]T, 0, batchSize)
+	for v := range seq {
+		empty = false
+		batch = append(batch, v)
+		if len(batch) < batchSize {
+			continue
+		}
+
+		if err := execBatch(batch); err != nil {
+			return rowsAffected, err
+		}
+		batch = batch[:0]
+	}
+	if len(batch) > 0 {
+		if err := execBatch(batch); err != nil {
+			return rowsAffected, err
+		}
+	}
+	if empty {
+		return 0, ErrNoInsertValues
+	}
+
+	return rowsAffected, nil
+}
+
+// ExecPrepared will insert the values of the InsertBuilder one row at a time, by preparing the single row insert query,
+// and executing it for each row. This keeps the args of each execution small, and lets the database reuse the plan of
+// the statement. A row with a different query, such as one using a default, has its own statement prepared. The total
+// rows affected by every row is returned.
+// If a row fails, the rows before it have already been inserted. To insert all or nothing, use a sql.Tx as the
+// Preparer, such as within WithTransaction.
+// If there are no values, then ErrNoInsertValues is returned, like with InsertBuilder.Exec. Any timeout set by
+// InsertBuilder.Timeout applies to inserting every row, within the deadline of ctx.
+func (b InsertBuilder[T]) ExecPrepared(ctx context.Context, db Preparer) (int64, error) {
+	if b.timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.timeout)
+		defer cancel()
+	}
+
+	seq := b.seqValues
+	if seq == nil {
+		seq = slices.Values(b.literalValues)
+	}
+
+	stmts := make(map[string]*sql.Stmt)
+	defer func() {
+		for _, stmt := range stmts {
+			_ = stmt.Close()
+		}
+	}()
+
+	var rowsAffected int64
+	empty := true
+	for v := range seq {
+		empty = false
+
+		query, args, err := b.Values(v).BuildQuery()
+		if err != nil {
+			return rowsAffected, err
+		}
+		query, args, err = rewriteQuery(query, args)
+		if err != nil {
+			return rowsAffected, err
+		}
+
+		stmt, ok := stmts[query]
+		if !ok {
+			stmt, err = db.PrepareContext(ctx, query)
+			if err != nil {
+				return rowsAffected, QueryError{query, args, err}
+			}
+			stmts[query] = stmt
+		}
+
+		result, err := stmt.ExecContext(ctx, args...)
+		if err != nil {
+			return rowsAffected, QueryError{query, args, err}
+		}
+
+		n, err := result.RowsAffected()
+		if err != nil {
+			return rowsAffected, err
+		}
+		rowsAffected += n
+	}
+	if empty {
+		return 0, ErrNoInsertValues
+	}
+
+	return rowsAffected, nil
 }
 
 // ExecContext will execute the insert query represented by the InsertBuilder.
-// This will execute using the provided sql.DB, and the response is simply passed back.
-func (b InsertBuilder[T]) ExecContext(ctx context.Context, db *sql.DB) (sql.Result, error) {
+// This will execute using the provided Executor, and the response is simply passed back.
+func (b InsertBuilder[T]) ExecContext(ctx context.Context, db Executor) (sql.Result, error) {
 	query, args, err := b.BuildQuery()
 	if err != nil {
 		return nil, err
 	}
 
-	return db.ExecContext(ctx, query, args...)
+	return execContext(ctx, db, query, args)
 }
@@ -1,8 +1,14 @@
 package qubr
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
 	"github.com/stretchr/testify/assert"
+	"reflect"
+	"slices"
 	"testing"
+	"time"
 )
 
 func TestInsert(t *testing.T) {
@@ -78,3 +84,265 @@ func TestInsertAndExec(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, int64(2), affected)
 }
+
+func TestInsertStrictExportedFields(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	query, _, err := Insert[bunny]().
+		StrictExportedFields().
+		Values(bunny{"oliver", 20}).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `INSERT INTO "bunny" VALUES (?, ?);`, query)
+
+	type secretBunny struct {
+		Name      string
+		EarLength float64
+
+		age int64
+	}
+
+	_, _, err = Insert[secretBunny]().
+		StrictExportedFields().
+		Values(secretBunny{"oliver", 20, 3}).
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrUnexportedField{"age"}, err)
+}
+
+func TestInsertNotAStruct(t *testing.T) {
+	_, _, err := Insert[[]string]().
+		Values([]string{"oliver"}).
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrNotAStruct{reflect.TypeFor[[]string]()}, err)
+}
+
+func TestInsertWithComment(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	query, _, err := Insert[bunny]().
+		WithComment("job=import").
+		Values(bunny{"oliver", 20}).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `/* job=import */ INSERT INTO "bunny" VALUES (?, ?);`, query)
+}
+
+func TestInsertFromSeqAndExecBatched(t *testing.T) {
+	type bunny struct {
+		Name string
+		Age  int64
+	}
+
+	db := SetupTestDatabase(t, `CREATE TABLE "bunny" ("Name" TEXT, "Age" INT);`)
+
+	// Five rows, so the last batch is smaller than the rest.
+	seq := func(yield func(bunny) bool) {
+		for i := range int64(5) {
+			if !yield(bunny{"ollie", i}) {
+				return
+			}
+		}
+	}
+
+	rowsAffected, err := Insert[bunny]().
+		FromSeq(seq).
+		ExecBatched(context.Background(), db, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), rowsAffected)
+
+	bunnies, err := Select[bunny]().Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", 0}, {"ollie", 1}, {"ollie", 2}, {"ollie", 3}, {"ollie", 4}}, bunnies)
+}
+
+func TestInsertExecBatchedQueries(t *testing.T) {
+	type bunny struct {
+		Name string
+	}
+
+	db := NewRecordingExecutor()
+	defer db.Close()
+
+	_, err := Insert[bunny]().
+		Values(bunny{"ollie"}, bunny{"oliver"}, bunny{"king ollie"}).
+		ExecBatched(context.Background(), db, 2)
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]RecordedQuery{
+			{`INSERT INTO "bunny" VALUES (?), (?);`, []any{"ollie", "oliver"}},
+			{`INSERT INTO "bunny" VALUES (?);`, []any{"king ollie"}},
+		},
+		db.Queries(),
+	)
+
+	_, err = Insert[bunny]().
+		Values(bunny{"ollie"}).
+		ExecBatched(context.Background(), db, 0)
+	assert.ErrorIs(t, err, ErrInvalidBatchSize)
+
+	// Nothing to insert is an error, like with Exec, whether there are no values or the sequence is empty.
+	_, err = Insert[bunny]().ExecBatched(context.Background(), db, 2)
+	assert.ErrorIs(t, err, ErrNoInsertValues)
+
+	_, err = Insert[bunny]().
+		FromSeq(slices.Values([]bunny{})).
+		ExecBatched(context.Background(), db, 2)
+	assert.ErrorIs(t, err, ErrNoInsertValues)
+	assert.Len(t, db.Queries(), 2)
+}
+
+func TestInsertExecBatchedRollback(t *testing.T) {
+	type bunny struct {
+		Name string
+		Age  int64
+	}
+
+	db := SetupTestDatabase(t, `CREATE TABLE "bunny" ("Name" TEXT, "Age" INT CHECK ("Age" < 3));`)
+
+	// The second batch breaks the check, so the first batch is rolled back along with it.
+	rowsAffected, err := Insert[bunny]().
+		Values(bunny{"ollie", 0}, bunny{"ollie", 1}, bunny{"ollie", 2}, bunny{"ollie", 3}).
+		ExecBatched(context.Background(), db, 2)
+	assert.Error(t, err)
+	assert.Zero(t, rowsAffected)
+
+	bunnies, err := Select[bunny]().Query(db)
+	assert.NoError(t, err)
+	assert.Empty(t, bunnies)
+}
+
+func TestInsertExecBatchedBuildError(t *testing.T) {
+	db := NewRecordingExecutor()
+	defer db.Close()
+
+	// The error in building is returned, rather than ErrNoInsertValues, even though the sequence is empty.
+	_, err := Insert[int]().
+		FromSeq(slices.Values([]int{})).
+		ExecBatched(context.Background(), db, 2)
+	assert.ErrorIs(t, err, ErrNotAStruct{reflect.TypeFor[int]()})
+	assert.Empty(t, db.Queries())
+}
+
+func TestInsertWithDefaultAndQuery(t *testing.T) {
+	type bunny struct {
+		Name  string
+		Age   int64  `db:"Age,default=1"`
+		Hutch string `db:"Hutch,default=lower('HUTCH')"`
+	}
+
+	query, args, err := Insert[bunny]().
+		Values(bunny{Name: "ollie"}, bunny{"oliver", 2, "burrow"}).
+		BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `INSERT INTO "bunny" VALUES (?, 1, lower('HUTCH')), (?, ?, ?);`, query)
+	assert.Equal(t, []any{"ollie", "oliver", int64(2), "burrow"}, args)
+
+	db := SetupTestDatabase(t, `CREATE TABLE "bunny" ("Name" TEXT, "Age" INT, "Hutch" TEXT);`)
+
+	_, err = db.Exec(query, args...)
+	assert.NoError(t, err)
+
+	bunnies, err := Select[bunny]().Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", 1, "hutch"}, {"oliver", 2, "burrow"}}, bunnies)
+}
+
+func TestInsertExecPrepared(t *testing.T) {
+	type bunny struct {
+		Name string
+		Age  int64 `db:"Age,default=1"`
+	}
+
+	db := SetupMemoryTestDatabase(t, `CREATE TABLE "bunny" ("Name" TEXT, "Age" INT);`)
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	rowsAffected, err := Insert[bunny]().
+		Values(bunny{"ollie", 2}, bunny{Name: "oliver"}, bunny{"king ollie", 3}).
+		ExecPrepared(context.Background(), tx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), rowsAffected)
+	assert.NoError(t, tx.Commit())
+
+	bunnies, err := Select[bunny]().Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", 2}, {"oliver", 1}, {"king ollie", 3}}, bunnies)
+
+	_, err = Insert[bunny]().
+		Values(bunny{"ollie", 2}).
+		Into("missing").
+		ExecPrepared(context.Background(), db)
+	var queryErr QueryError
+	assert.ErrorAs(t, err, &queryErr)
+	assert.Equal(t, `INSERT INTO "missing" VALUES (?, ?);`, queryErr.Query)
+
+	_, err = Insert[bunny]().ExecPrepared(context.Background(), db)
+	assert.ErrorIs(t, err, ErrNoInsertValues)
+
+	// The timeout applies, even though a context is given.
+	_, err = Insert[bunny]().
+		Values(bunny{"ollie", 2}).
+		Timeout(time.Nanosecond).
+		ExecPrepared(context.Background(), db)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+type benchmarkBunny struct {
+	Name string
+	Age  int64
+}
+
+// benchmarkInsert will run insert against a fresh in-memory database for each iteration, with 100 bunnies to insert.
+func benchmarkInsert(b *testing.B, insert func(db *sql.DB, bunnies []benchmarkBunny) error) {
+	bunnies := make([]benchmarkBunny, 100)
+	for i := range bunnies {
+		bunnies[i] = benchmarkBunny{fmt.Sprintf("ollie %d", i), int64(i)}
+	}
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+	// Each connection to ":memory:" is its own database, so only one is kept.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(`CREATE TABLE "benchmarkBunny" ("Name" TEXT, "Age" INT);`); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for range b.N {
+		if err := insert(db, bunnies); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkInsertExec(b *testing.B) {
+	benchmarkInsert(b, func(db *sql.DB, bunnies []benchmarkBunny) error {
+		_, err := Insert[benchmarkBunny]().Values(bunnies...).Exec(db)
+		return err
+	})
+}
+
+func BenchmarkInsertExecPrepared(b *testing.B) {
+	benchmarkInsert(b, func(db *sql.DB, bunnies []benchmarkBunny) error {
+		return WithTransaction(context.Background(), db, func(tx *sql.Tx) error {
+			_, err := Insert[benchmarkBunny]().Values(bunnies...).ExecPrepared(context.Background(), tx)
+			return err
+		})
+	})
+}
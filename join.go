@@ -0,0 +1,35 @@
+package qubr
+
+import (
+	"fmt"
+	"strings"
+)
+
+type joinKind uint8
+
+const (
+	joinKindUsing joinKind = iota
+	joinKindNatural
+)
+
+type join struct {
+	kind joinKind
+
+	table   tableName
+	columns []string
+}
+
+func (j join) String() string {
+	switch j.kind {
+	case joinKindNatural:
+		return fmt.Sprintf(" NATURAL JOIN %s", j.table)
+	default:
+		// ("X", "Y")
+		sb := strings.Builder{}
+		for _, column := range j.columns {
+			sb.WriteString(fmt.Sprintf(`"%s", `, column))
+		}
+
+		return fmt.Sprintf(" JOIN %s USING (%s)", j.table, strings.TrimSuffix(sb.String(), ", "))
+	}
+}
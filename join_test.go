@@ -0,0 +1,35 @@
+package qubr
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_join_String(t1 *testing.T) {
+	tests := []struct {
+		name string
+		join join
+		want string
+	}{
+		{
+			name: "using a single column",
+			join: join{kind: joinKindUsing, table: tableName{tableName: "carrots"}, columns: []string{"ID"}},
+			want: ` JOIN "carrots" USING ("ID")`,
+		},
+		{
+			name: "using many columns",
+			join: join{kind: joinKindUsing, table: tableName{tableName: "carrots"}, columns: []string{"ID", "Farm"}},
+			want: ` JOIN "carrots" USING ("ID", "Farm")`,
+		},
+		{
+			name: "natural join with schema",
+			join: join{kind: joinKindNatural, table: tableName{schema: "farm", tableName: "carrots"}},
+			want: ` NATURAL JOIN "farm"."carrots"`,
+		},
+	}
+	for _, tt := range tests {
+		t1.Run(tt.name, func(t1 *testing.T) {
+			assert.Equalf(t1, tt.want, tt.join.String(), "String()")
+		})
+	}
+}
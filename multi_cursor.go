@@ -0,0 +1,54 @@
+package qubr
+
+import (
+	"context"
+	"database/sql"
+)
+
+// MultiCursor steps through each of the result sets of a query, which can differ in type.
+// Use NextSet to map each result set, and MultiCursor.Close once done.
+// Example:
+//
+//	cursor, err := QueryMultiContext(ctx, db, "CALL user_and_orders(?);", 42)
+//	if err != nil {
+//		return err
+//	}
+//	defer cursor.Close()
+//
+//	users, err := NextSet[User](cursor)
+//	orders, err := NextSet[Order](cursor)
+type MultiCursor struct {
+	rows    *sql.Rows
+	started bool
+}
+
+// QueryMultiContext will run the query, utilizing the Executor provided, for a query which results in multiple result
+// sets, such as some stored procedures. Not every driver supports multiple result sets, in which case, only the first
+// is available.
+func QueryMultiContext(ctx context.Context, db Executor, query string, args ...any) (*MultiCursor, error) {
+	rows, err := rowsContext(ctx, db, query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MultiCursor{rows: rows}, nil
+}
+
+// NextSet will map all rows of the next result set of the MultiCursor to T.
+// The first call maps the first result set. Once there are no result sets left, ErrNoMoreResultSets is returned.
+func NextSet[T any](c *MultiCursor) ([]T, error) {
+	if c.started && !c.rows.NextResultSet() {
+		if err := c.rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, ErrNoMoreResultSets
+	}
+	c.started = true
+
+	return scanRows[T](c.rows, scanOptions{})
+}
+
+// Close will close the underlying sql.Rows, it is safe to call this more than once.
+func (c *MultiCursor) Close() error {
+	return c.rows.Close()
+}
@@ -0,0 +1,35 @@
+package qubr
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestQueryMultiContext(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	db := SetupMemoryTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "EarLength" FLOAT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 15)`,
+		`INSERT INTO "bunny" VALUES('oliver', 20)`,
+	)
+
+	cursor, err := QueryMultiContext(context.Background(), db, `SELECT * FROM "bunny" WHERE "EarLength" > ?;`, 16)
+	assert.NoError(t, err)
+	defer cursor.Close()
+
+	bunnies, err := NextSet[bunny](cursor)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"oliver", 20}}, bunnies)
+
+	// sqlite only ever has the one result set.
+	_, err = NextSet[bunny](cursor)
+	assert.ErrorIs(t, err, ErrNoMoreResultSets)
+
+	assert.NoError(t, cursor.Close())
+}
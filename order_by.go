@@ -0,0 +1,46 @@
+package qubr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SortDirection is the direction results are sorted in, by SelectBuilder.OrderBy.
+type SortDirection uint8
+
+const (
+	Ascending SortDirection = iota
+	Descending
+)
+
+func (d SortDirection) String() string {
+	var s string
+	switch d {
+	case Ascending:
+		s = "ASC"
+	case Descending:
+		s = "DESC"
+	}
+	return s
+}
+
+type orderBy struct {
+	field     string
+	direction SortDirection
+}
+
+// orderByClause will construct the ORDER BY clause for the terms, with the field names replaced using fn.
+// If there are no terms, nothing is returned.
+func orderByClause(terms []orderBy, fn func(name string) string) string {
+	if len(terms) == 0 {
+		return ""
+	}
+
+	// "X" ASC, "Y" DESC
+	rendered := make([]string, len(terms))
+	for i, term := range terms {
+		rendered[i] = fmt.Sprintf(`"%s" %s`, fn(term.field), term.direction)
+	}
+
+	return " ORDER BY " + strings.Join(rendered, ", ")
+}
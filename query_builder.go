@@ -1,5 +1,19 @@
 package qubr
 
+import (
+	"context"
+	"time"
+)
+
 type QueryBuilder interface {
 	BuildQuery() (query string, args []any, err error)
 }
+
+// timeoutContext is the context used by the methods of a builder which do not take one. If timeout is zero, there is
+// no deadline.
+func timeoutContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout == 0 {
+		return context.Background(), func() {}
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
@@ -3,54 +3,317 @@ package qubr
 import (
 	"context"
 	"database/sql"
+	"math"
 	"reflect"
+	"slices"
+	"strings"
+	"time"
 )
 
-// QueryContext is a wrapper for sql.DB's QueryContext function.
-// The rows are mapped to T, where each field of T is a column in the row.
-func QueryContext[T any](ctx context.Context, db *sql.DB, query string, args ...any) ([]T, error) {
-	rows, err := db.QueryContext(ctx, query, args...)
+// scanOptions alter how the rows are mapped in queryContext.
+type scanOptions struct {
+	timeFormat    TimeFormat
+	columnMatch   ColumnMatch
+	strictColumns bool
+
+	// selectedColumns are the names of the columns of the fields which were selected, or nil for every field. A field
+	// which was not selected is not expected to have a column, even with strict columns.
+	selectedColumns []string
+}
+
+// ColumnMatch is how the names of the columns in a result are matched to the names of the fields of a struct.
+// The name of a field is the name given in its "db" tag, if it has one, otherwise, its name in the struct.
+type ColumnMatch uint8
+
+const (
+	// ColumnMatchExact will only match a column to a field with exactly the same name, which is the default.
+	ColumnMatchExact ColumnMatch = iota
+	// ColumnMatchCaseInsensitive will match a column to a field, ignoring differences in case.
+	// For example, "earlength" matches "EarLength".
+	ColumnMatchCaseInsensitive
+	// ColumnMatchLoose will match a column to a field, ignoring differences in case, and any underscores.
+	// For example, "ear_length" matches "EarLength". This is the most likely to match a column to the wrong field.
+	ColumnMatchLoose
+)
+
+func (m ColumnMatch) normalize(name string) string {
+	switch m {
+	case ColumnMatchCaseInsensitive:
+		return strings.ToLower(name)
+	case ColumnMatchLoose:
+		return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+	default:
+		return name
+	}
+}
+
+// QueryContext is a wrapper for the Executor's QueryContext function.
+// The rows are mapped to T, where each column in the row is mapped to the field of T with the same name.
+// Columns without a matching field are ignored, and fields without a matching column are left as the zero value.
+func QueryContext[T any](ctx context.Context, db Executor, query string, args ...any) ([]T, error) {
+	return queryContext[T](ctx, db, scanOptions{}, query, args...)
+}
+
+// QueryRowContext is a wrapper for the Executor's QueryRowContext function.
+// The first row is mapped to T, in the same way as QueryContext. If there are no rows, then ErrNoRows is returned.
+func QueryRowContext[T any](ctx context.Context, db Executor, query string, args ...any) (*T, error) {
+	return queryRowContext[T](ctx, db, scanOptions{}, query, args...)
+}
+
+// Ping wraps PingContext, which will check that a trivial query can be run using the Executor.
+func Ping(db Executor) error {
+	return PingContext(context.Background(), db)
+}
+
+// PingContext will run "SELECT 1;" using the Executor, and check that the expected result is returned.
+// Unlike sql.DB's PingContext, this makes sure a query can actually be run, and not just that a connection is alive.
+func PingContext(ctx context.Context, db Executor) error {
+	var one int
+	if err := db.QueryRowContext(ctx, "SELECT 1;").Scan(&one); err != nil {
+		return err
+	}
+
+	if one != 1 {
+		return ErrUnexpectedPing
+	}
+
+	return nil
+}
+
+func queryContext[T any](ctx context.Context, db Executor, opts scanOptions, query string, args ...any) ([]T, error) {
+	if err := checkStructType(reflect.TypeFor[T]()); err != nil {
+		return nil, err
+	}
+
+	rows, err := rowsContext(ctx, db, query, args)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	selectType := reflect.TypeFor[T]()
+	return scanRows[T](rows, opts)
+}
+
+func queryRowContext[T any](ctx context.Context, db Executor, opts scanOptions, query string, args ...any) (*T, error) {
+	if err := checkStructType(reflect.TypeFor[T]()); err != nil {
+		return nil, err
+	}
+
+	// Unlike sql.Row, sql.Rows has the column names, which we need to map by.
+	rows, err := rowsContext(ctx, db, query, args)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fields, err := newScanFields[T](rows, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, ErrNoRows
+	}
+
+	t, err := scanRow[T](rows.Scan, fields, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+// scanRows will map each of the remaining rows in the current result set to T.
+func scanRows[T any](rows *sql.Rows, opts scanOptions) ([]T, error) {
+	fields, err := newScanFields[T](rows, opts)
+	if err != nil {
+		return nil, err
+	}
 
 	// No way to determine the number of rows, other than by simply scanning one-by-one.
 	var mapped []T
 	for rows.Next() {
-		mappedValue := reflect.ValueOf(new(T)).Elem()
+		t, err := scanRow[T](rows.Scan, fields, opts)
+		if err != nil {
+			return nil, err
+		}
 
-		numField := selectType.NumField()
+		mapped = append(mapped, t)
+	}
 
-		// Create pointers for "Scan" to populate row values onto a temporary "values" array.
-		numExported := 0 // Our end number of rows may not actually be equal to "numField" due to unexported fields.
-		values := make([]any, numField)
-		for i := range numField {
-			f := mappedValue.Field(i)
-			if !f.CanSet() {
-				continue
-			}
+	return mapped, rows.Err()
+}
 
-			field := f.Interface()
-			values[numExported] = &field
+// scanField is a field of the struct being mapped to, and how its value is decoded.
+type scanField struct {
+	reflect.StructField
+	transform *transform
+}
+
+// newScanFields will determine the field of T which each of the columns of the rows are mapped to.
+// Columns are matched to fields by name, using the ColumnMatch of the options. If a column has no matching field, then
+// it is nil, and the value will be discarded. If more than one column matches a field, the first is used.
+// With strict columns, every column must match exactly one field, and every selected field a column, or
+// ErrColumnMismatch occurs.
+func newScanFields[T any](rows *sql.Rows, opts scanOptions) ([]*scanField, error) {
+	selectType := reflect.TypeFor[T]()
+	if err := checkStructType(selectType); err != nil {
+		return nil, err
+	}
 
-			numExported++
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]*scanField, len(columns))
+	for i := range selectType.NumField() {
+		f := selectType.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		name := opts.columnMatch.normalize(structFieldName(f))
+		column := slices.IndexFunc(columns, func(column string) bool {
+			return opts.columnMatch.normalize(column) == name
+		})
+		if column < 0 || fields[column] != nil {
+			// A lazy field is only selected when asked for, and a field left out of the selection is not selected at
+			// all, so neither is expected to have a column.
+			_, lazy := structFieldOption(f, "lazy")
+			selected := opts.selectedColumns == nil || slices.Contains(opts.selectedColumns, structFieldName(f))
+			if opts.strictColumns && !lazy && selected {
+				return nil, ErrColumnMismatch{Field: f.Name}
+			}
+
+			// Nothing for this field, it will be left as the zero value.
+			continue
 		}
 
-		// Pull out the row values.
-		if err = rows.Scan(values[:numExported]...); err != nil {
+		t, err := fieldTransform(f)
+		if err != nil {
 			return nil, err
 		}
 
-		// Set the row values onto a new "T", field by field.
-		for i := range numExported {
-			mappedValue.Field(i).Set(reflect.ValueOf(*values[i].(*any)))
+		fields[column] = &scanField{f, t}
+	}
+
+	if opts.strictColumns {
+		if i := slices.Index(fields, nil); i >= 0 {
+			return nil, ErrColumnMismatch{Column: columns[i]}
 		}
+	}
+
+	return fields, nil
+}
 
-		// Finally, we have our new element
-		mapped = append(mapped, mappedValue.Interface().(T))
+// scanRow will map a single row to T, using the scan function of sql.Rows.
+func scanRow[T any](scan func(dest ...any) error, fields []*scanField, opts scanOptions) (T, error) {
+	timeType := reflect.TypeFor[time.Time]()
+
+	mappedValue := reflect.ValueOf(new(T)).Elem()
+
+	// Create pointers for "Scan" to populate row values onto a temporary "values" array.
+	// Columns without a field are still scanned, but their value is never used.
+	values := make([]any, len(fields))
+	for i := range fields {
+		values[i] = new(any)
+	}
+
+	// Pull out the row values.
+	if err := scan(values...); err != nil {
+		return *new(T), err
+	}
+
+	// Set the row values onto a new "T", field by field.
+	for i, f := range fields {
+		v := *values[i].(*any)
+		if f == nil || v == nil {
+			// No field for this column, or the value was NULL, which leaves the field as the zero value.
+			continue
+		}
+
+		var err error
+		if f.transform != nil {
+			v, err = f.transform.decode(v)
+		} else if opts.timeFormat != TimeFormatNative && f.Type == timeType {
+			v, err = opts.timeFormat.decode(v)
+		}
+		if err != nil {
+			return *new(T), err
+		}
+
+		if err := setScannedValue(mappedValue.FieldByIndex(f.Index), f.Name, v); err != nil {
+			return *new(T), err
+		}
 	}
 
-	return mapped, nil
+	// Finally, we have our new element
+	return mappedValue.Interface().(T), nil
+}
+
+// setScannedValue will set the value scanned from a column onto the field. If the value is not assignable, such as an
+// int64 for a uint16 field, or a string for a named string type, then it is converted to the type of the field.
+// A number which does not fit in the type of the field is not assignable, rather than being truncated.
+func setScannedValue(field reflect.Value, name string, v any) error {
+	value := reflect.ValueOf(v)
+	switch {
+	case value.Type().AssignableTo(field.Type()):
+		field.Set(value)
+	case isScanConvertible(value.Type(), field.Type()) && !scanOverflows(value, field):
+		field.Set(value.Convert(field.Type()))
+	default:
+		return ErrUnassignableValue{name, value.Type(), field.Type()}
+	}
+	return nil
+}
+
+// scanOverflows will report whether the number value cannot be represented by the numeric field, so converting it
+// would silently truncate, or wrap around. Anything other than a conversion between numbers never overflows.
+func scanOverflows(value reflect.Value, field reflect.Value) bool {
+	switch {
+	case value.CanInt():
+		i := value.Int()
+		switch {
+		case field.CanInt():
+			return field.OverflowInt(i)
+		case field.CanUint():
+			return i < 0 || field.OverflowUint(uint64(i))
+		case field.CanFloat():
+			return field.OverflowFloat(float64(i))
+		}
+	case value.CanUint():
+		u := value.Uint()
+		switch {
+		case field.CanInt():
+			return u > math.MaxInt64 || field.OverflowInt(int64(u))
+		case field.CanUint():
+			return field.OverflowUint(u)
+		case field.CanFloat():
+			return field.OverflowFloat(float64(u))
+		}
+	case value.CanFloat():
+		f := value.Float()
+		switch {
+		case field.CanInt():
+			return f < math.MinInt64 || f >= math.MaxInt64 || field.OverflowInt(int64(f))
+		case field.CanUint():
+			return f < 0 || f >= math.MaxUint64 || field.OverflowUint(uint64(f))
+		case field.CanFloat():
+			return field.OverflowFloat(f)
+		}
+	}
+	return false
+}
+
+// isScanConvertible will report whether a scanned value of type from can be converted to the type to.
+// Unlike reflect.Type.ConvertibleTo, an integer is never converted to a string, which would result in a single rune.
+func isScanConvertible(from reflect.Type, to reflect.Type) bool {
+	if to.Kind() == reflect.String && from.Kind() != reflect.String && from.Kind() != reflect.Slice {
+		return false
+	}
+	return from.ConvertibleTo(to)
 }
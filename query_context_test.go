@@ -0,0 +1,226 @@
+package qubr
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestQueryContextAdHocComputedColumn(t *testing.T) {
+	// Only exists for the shape of this one query, and is not a table.
+	type earLengthByName struct {
+		Name         string
+		MaxEarLength float64
+		NumberOfBuns int64
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "EarLength" FLOAT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 15)`,
+		`INSERT INTO "bunny" VALUES('ollie', 20)`,
+		`INSERT INTO "bunny" VALUES('king ollie', 30)`,
+	)
+
+	rows, err := QueryContext[earLengthByName](
+		context.Background(),
+		db,
+		`SELECT "Name", MAX("EarLength") AS "MaxEarLength", COUNT(*) AS "NumberOfBuns" `+
+			`FROM "bunny" GROUP BY "Name" ORDER BY "Name";`,
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []earLengthByName{{"king ollie", 30, 1}, {"ollie", 20, 2}}, rows)
+}
+
+func TestQueryContextColumnsByName(t *testing.T) {
+	type bunny struct {
+		EarLength float64
+		Name      string
+		Nickname  string
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "Age" INT, "EarLength" FLOAT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 2, 15)`,
+	)
+
+	// Column order doesn't matter, "Age" has no field, and "Nickname" has no column.
+	rows, err := QueryContext[bunny](context.Background(), db, `SELECT * FROM "bunny";`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{15, "ollie", ""}}, rows)
+}
+
+func Test_queryContextColumnMatch(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("name" TEXT, "ear_length" FLOAT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 15)`,
+	)
+
+	tests := []struct {
+		name  string
+		match ColumnMatch
+		want  []bunny
+	}{
+		{name: "exact", match: ColumnMatchExact, want: []bunny{{}}},
+		{name: "case insensitive", match: ColumnMatchCaseInsensitive, want: []bunny{{Name: "ollie"}}},
+		{name: "loose", match: ColumnMatchLoose, want: []bunny{{"ollie", 15}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := queryContext[bunny](
+				context.Background(),
+				db,
+				scanOptions{columnMatch: tt.match},
+				`SELECT * FROM "bunny";`,
+			)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, rows)
+		})
+	}
+}
+
+func TestQueryContextConvertsNamedTypes(t *testing.T) {
+	type status string
+	type bunny struct {
+		Name      string
+		Status    status
+		AgeMonths uint16
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "Status" TEXT, "AgeMonths" INT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 'hungry', 26)`,
+	)
+
+	rows, err := QueryContext[bunny](context.Background(), db, `SELECT * FROM "bunny";`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", "hungry", 26}}, rows)
+}
+
+func TestQueryContextUnassignableValue(t *testing.T) {
+	type bunny struct {
+		Name string
+	}
+
+	db := SetupTestDatabase(t)
+
+	// An integer is never converted to a string.
+	_, err := QueryContext[bunny](context.Background(), db, `SELECT 65 AS "Name";`)
+
+	assert.ErrorIs(t, err, ErrUnassignableValue{"Name", reflect.TypeFor[int64](), reflect.TypeFor[string]()})
+}
+
+func TestQueryContextOverflowingValue(t *testing.T) {
+	type bunny struct {
+		Age       uint8
+		Carrots   uint64
+		EarLength float32
+	}
+
+	db := SetupTestDatabase(t)
+
+	rows, err := QueryContext[bunny](context.Background(), db, `SELECT 255 AS "Age", 12 AS "Carrots", 15.5 AS "EarLength";`)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{255, 12, 15.5}}, rows)
+
+	// 300 does not fit in a uint8, which would otherwise be truncated to 44.
+	_, err = QueryContext[bunny](context.Background(), db, `SELECT 300 AS "Age";`)
+	assert.ErrorIs(t, err, ErrUnassignableValue{"Age", reflect.TypeFor[int64](), reflect.TypeFor[uint8]()})
+
+	_, err = QueryContext[bunny](context.Background(), db, `SELECT -1 AS "Carrots";`)
+	assert.ErrorIs(t, err, ErrUnassignableValue{"Carrots", reflect.TypeFor[int64](), reflect.TypeFor[uint64]()})
+
+	_, err = QueryContext[bunny](context.Background(), db, `SELECT 1e300 AS "EarLength";`)
+	assert.ErrorIs(t, err, ErrUnassignableValue{"EarLength", reflect.TypeFor[float64](), reflect.TypeFor[float32]()})
+}
+
+func TestQueryRowContext(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+
+		age int64
+	}
+
+	db := SetupMemoryTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "EarLength" FLOAT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 15)`,
+		`INSERT INTO "bunny" VALUES('oliver', 20)`,
+	)
+
+	oliver, err := QueryRowContext[bunny](
+		context.Background(),
+		db,
+		`SELECT "Name", "EarLength" FROM "bunny" WHERE "EarLength" > ?;`,
+		15,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, &bunny{"oliver", 20, 0}, oliver)
+
+	_, err = QueryRowContext[bunny](
+		context.Background(),
+		db,
+		`SELECT "Name", "EarLength" FROM "bunny" WHERE "EarLength" > ?;`,
+		100,
+	)
+	assert.ErrorIs(t, err, ErrNoRows)
+}
+
+func TestPing(t *testing.T) {
+	db := SetupMemoryTestDatabase(t)
+
+	assert.NoError(t, Ping(db))
+
+	assert.NoError(t, db.Close())
+	assert.Error(t, Ping(db))
+}
+
+func TestQueryError(t *testing.T) {
+	type bunny struct {
+		Name string
+	}
+
+	errRecorded := errors.New("query recorded")
+	db := NewRecordingExecutor().StubErr(errRecorded)
+	defer db.Close()
+
+	_, err := Select[bunny]().Where(Equal("Name", "ollie")).Query(db)
+
+	var queryErr QueryError
+	assert.ErrorAs(t, err, &queryErr)
+	assert.ErrorIs(t, err, errRecorded)
+	assert.Equal(t, QueryError{`SELECT "Name" FROM "bunny" WHERE "Name" = ?;`, []any{"ollie"}, errRecorded}, queryErr)
+
+	// The args are never in the message.
+	assert.EqualError(t, err, `query failed: query recorded: SELECT "Name" FROM "bunny" WHERE "Name" = ?; (1 args)`)
+}
+
+func TestQueryErrorFromDriver(t *testing.T) {
+	type bunny struct {
+		Name string
+	}
+
+	db := SetupTestDatabase(t)
+
+	_, err := Insert[bunny]().Values(bunny{"ollie"}).Exec(db)
+
+	var queryErr QueryError
+	assert.ErrorAs(t, err, &queryErr)
+	assert.Equal(t, `INSERT INTO "bunny" VALUES (?);`, queryErr.Query)
+	assert.ErrorContains(t, queryErr.Err, "no such table")
+}
@@ -0,0 +1,81 @@
+package qubr
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// QueryRewriter can change a query, and its args, after it has been built, and before it is run.
+// This is an advanced feature for concerns which apply to every query, such as adding a tenant filter, or a routing hint
+// for a proxy. The rewritten query is given to the driver as it is, so the QueryRewriter is responsible for keeping it
+// valid, and for keeping the args in line with its placeholders.
+type QueryRewriter interface {
+	Rewrite(query string, args []any) (string, []any, error)
+}
+
+var (
+	queryRewriterMu sync.RWMutex
+	queryRewriter   QueryRewriter
+)
+
+// SetQueryRewriter will set the QueryRewriter which is used for every query run by qubr, other than by PingContext.
+// This includes QueryContext, and the other functions which take a hand-written query. The builders use it when running
+// their query, but BuildQuery does not, so the query it returns is never rewritten. By default, there is none, and
+// passing nil will remove it.
+func SetQueryRewriter(r QueryRewriter) {
+	queryRewriterMu.Lock()
+	defer queryRewriterMu.Unlock()
+
+	queryRewriter = r
+}
+
+// rewriteQuery will apply the QueryRewriter to the query, if there is one.
+func rewriteQuery(query string, args []any) (string, []any, error) {
+	queryRewriterMu.RLock()
+	r := queryRewriter
+	queryRewriterMu.RUnlock()
+
+	if r == nil {
+		return query, args, nil
+	}
+	return r.Rewrite(query, args)
+}
+
+// execContext will run ExecContext on the Executor, once the query has been rewritten.
+func execContext(ctx context.Context, db Executor, query string, args []any) (sql.Result, error) {
+	query, args, err := rewriteQuery(query, args)
+	if err != nil {
+		return nil, err
+	}
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return nil, QueryError{query, args, err}
+	}
+	return result, nil
+}
+
+// rowsContext will run QueryContext on the Executor, once the query has been rewritten.
+func rowsContext(ctx context.Context, db Executor, query string, args []any) (*sql.Rows, error) {
+	query, args, err := rewriteQuery(query, args)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, QueryError{query, args, err}
+	}
+	return rows, nil
+}
+
+// scanOneContext will run QueryRowContext on the Executor, once the query has been rewritten, scanning the row into dest.
+func scanOneContext(ctx context.Context, db Executor, query string, args []any, dest ...any) error {
+	query, args, err := rewriteQuery(query, args)
+	if err != nil {
+		return err
+	}
+	if err = db.QueryRowContext(ctx, query, args...).Scan(dest...); err != nil {
+		return QueryError{query, args, err}
+	}
+	return nil
+}
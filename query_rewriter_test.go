@@ -0,0 +1,54 @@
+package qubr
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// farmRewriter only allows bunnies from a single farm to be seen, like a tenant filter.
+type farmRewriter struct {
+	farm string
+}
+
+func (r farmRewriter) Rewrite(query string, args []any) (string, []any, error) {
+	if !strings.HasPrefix(query, "SELECT") {
+		return "", nil, errors.New("only selects allowed")
+	}
+	return strings.Replace(query, ` FROM "bunny"`, ` FROM (SELECT * FROM "bunny" WHERE "Farm" = ?)`, 1),
+		append([]any{r.farm}, args...),
+		nil
+}
+
+func TestSetQueryRewriter(t *testing.T) {
+	type bunny struct {
+		Name string
+		Farm string
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "Farm" TEXT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 'ollie''s farm')`,
+		`INSERT INTO "bunny" VALUES('oliver', 'ollie''s farm')`,
+		`INSERT INTO "bunny" VALUES('king ollie', 'the castle')`,
+	)
+
+	SetQueryRewriter(farmRewriter{"the castle"})
+	t.Cleanup(func() { SetQueryRewriter(nil) })
+
+	bunnies, err := Select[bunny]().
+		Where(NotEqual("Name", "oliver")).
+		Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"king ollie", "the castle"}}, bunnies)
+
+	// The built query is never rewritten.
+	query, _, err := Select[bunny]().BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Name", "Farm" FROM "bunny";`, query)
+
+	_, err = Delete[bunny]().Where(Equal("Name", "ollie")).Exec(db)
+	assert.EqualError(t, err, "only selects allowed")
+}
@@ -0,0 +1,16 @@
+package qubr
+
+import "strings"
+
+// newRawColumn will construct a column of raw SQL in a select, which is named by its alias.
+// The expression is placed into the query as it is, so only that it is not empty can be checked.
+func newRawColumn(expr string, alias string) (aliasedColumn, error) {
+	if strings.TrimSpace(expr) == "" {
+		return aliasedColumn{}, ErrEmptyRawColumn
+	}
+	if alias == "" || strings.Contains(alias, `"`) {
+		return aliasedColumn{}, ErrInvalidColumnName{alias}
+	}
+
+	return aliasedColumn{expr: expr, alias: alias, raw: true}, nil
+}
@@ -0,0 +1,244 @@
+package qubr
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"slices"
+	"sync"
+)
+
+// RecordingExecutor is an Executor which records every query it is given, without a real database behind it.
+// This is useful for testing code which runs queries, by checking what would have been run.
+// By default, each query results in no rows, and each exec affects no rows. The results can be stubbed, in the order
+// they are run, using RecordingExecutor.StubRows, RecordingExecutor.StubResult, and RecordingExecutor.StubErr.
+// Example:
+//
+//	db := NewRecordingExecutor().
+//		StubRows([]string{"ID", "Name"}, []any{int64(42), "ollie"})
+//	defer db.Close()
+//
+//	user, err := Select[User]().GetOne(db)
+//	assert.Equal(t, []RecordedQuery{{`SELECT "ID", "Name" FROM "User" LIMIT ?;`, []any{uint64(1)}}}, db.Queries())
+type RecordingExecutor struct {
+	db *sql.DB
+
+	mu      sync.Mutex
+	queries []RecordedQuery
+	stubs   []recordingStub
+}
+
+var _ Executor = (*RecordingExecutor)(nil)
+
+// RecordedQuery is a query given to a RecordingExecutor, along with its args.
+type RecordedQuery struct {
+	Query string
+	Args  []any
+}
+
+// recordingStub is the result of a query run against a RecordingExecutor. If err is set, then the query fails.
+type recordingStub struct {
+	columns []string
+	rows    [][]any
+	result  recordingResult
+	err     error
+}
+
+// NewRecordingExecutor will construct a new RecordingExecutor, with no stubbed results.
+func NewRecordingExecutor() *RecordingExecutor {
+	e := &RecordingExecutor{}
+	e.db = sql.OpenDB(recordingConnector{e})
+	return e
+}
+
+// StubRows will stub the result of the next query which has not been stubbed, with the columns and rows given.
+// Each row must have one value for each column, and the values must be those a driver would return, such as int64,
+// float64, bool, []byte, string, time.Time, or nil.
+// If the stubbed query is an exec, then the rows are ignored.
+// If a row does not have one value for each column, then the stubbed query fails with an ErrStubRowArity.
+func (e *RecordingExecutor) StubRows(columns []string, rows ...[]any) *RecordingExecutor {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	stub := recordingStub{columns: columns, rows: rows}
+	for _, row := range rows {
+		if len(row) != len(columns) {
+			stub = recordingStub{err: ErrStubRowArity{len(columns), len(row)}}
+			break
+		}
+	}
+
+	e.stubs = append(e.stubs, stub)
+	return e
+}
+
+// StubResult will stub the result of the next query which has not been stubbed, so that an exec results in the rows
+// affected and last insert ID given. If the stubbed query is not an exec, then it results in no rows.
+func (e *RecordingExecutor) StubResult(rowsAffected, lastInsertID int64) *RecordingExecutor {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.stubs = append(e.stubs, recordingStub{result: recordingResult{rowsAffected, lastInsertID}})
+	return e
+}
+
+// StubErr will stub the result of the next query which has not been stubbed, so that it fails with err.
+func (e *RecordingExecutor) StubErr(err error) *RecordingExecutor {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.stubs = append(e.stubs, recordingStub{err: err})
+	return e
+}
+
+// Queries are all the queries which have been run, in the order they were run.
+func (e *RecordingExecutor) Queries() []RecordedQuery {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return slices.Clone(e.queries)
+}
+
+// Close will release the resources of the RecordingExecutor. It cannot be used after this.
+func (e *RecordingExecutor) Close() error {
+	return e.db.Close()
+}
+
+// ExecContext will record the query, and result in the next stub.
+func (e *RecordingExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	e.record(query, args)
+	return e.db.ExecContext(ctx, query, args...)
+}
+
+// QueryContext will record the query, and result in the next stub.
+func (e *RecordingExecutor) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	e.record(query, args)
+	return e.db.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext will record the query, and result in the next stub.
+func (e *RecordingExecutor) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	e.record(query, args)
+	return e.db.QueryRowContext(ctx, query, args...)
+}
+
+func (e *RecordingExecutor) record(query string, args []any) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.queries = append(e.queries, RecordedQuery{query, args})
+}
+
+// nextStub will take the next stubbed result, or an empty one, if there are none left.
+func (e *RecordingExecutor) nextStub() recordingStub {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.stubs) == 0 {
+		return recordingStub{}
+	}
+
+	stub := e.stubs[0]
+	e.stubs = e.stubs[1:]
+	return stub
+}
+
+// The rest is a minimal driver, which gives the stubbed results back through database/sql, so that a sql.Rows or
+// sql.Row can be returned.
+
+var errRecordingUnsupported = errors.New("not supported by a recording executor")
+
+type recordingConnector struct {
+	e *RecordingExecutor
+}
+
+func (c recordingConnector) Connect(context.Context) (driver.Conn, error) {
+	return recordingConn(c), nil
+}
+
+func (c recordingConnector) Driver() driver.Driver {
+	return recordingDriver{}
+}
+
+type recordingDriver struct{}
+
+func (recordingDriver) Open(string) (driver.Conn, error) {
+	return nil, errRecordingUnsupported
+}
+
+type recordingConn struct {
+	e *RecordingExecutor
+}
+
+func (c recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errRecordingUnsupported
+}
+
+func (c recordingConn) Close() error {
+	return nil
+}
+
+func (c recordingConn) Begin() (driver.Tx, error) {
+	return nil, errRecordingUnsupported
+}
+
+// CheckNamedValue accepts any arg as it is, since the args are never given to a real database.
+func (c recordingConn) CheckNamedValue(*driver.NamedValue) error {
+	return nil
+}
+
+func (c recordingConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	stub := c.e.nextStub()
+	if stub.err != nil {
+		return nil, stub.err
+	}
+	return stub.result, nil
+}
+
+type recordingResult struct {
+	rowsAffected int64
+	lastInsertID int64
+}
+
+func (r recordingResult) LastInsertId() (int64, error) {
+	return r.lastInsertID, nil
+}
+
+func (r recordingResult) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
+}
+
+func (c recordingConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	stub := c.e.nextStub()
+	if stub.err != nil {
+		return nil, stub.err
+	}
+	return &recordingRows{columns: stub.columns, rows: stub.rows}, nil
+}
+
+type recordingRows struct {
+	columns []string
+	rows    [][]any
+}
+
+func (r *recordingRows) Columns() []string {
+	return r.columns
+}
+
+func (r *recordingRows) Close() error {
+	return nil
+}
+
+func (r *recordingRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+
+	for i, v := range r.rows[0] {
+		dest[i] = v
+	}
+	r.rows = r.rows[1:]
+	return nil
+}
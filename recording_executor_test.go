@@ -0,0 +1,75 @@
+package qubr
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRecordingExecutor(t *testing.T) {
+	type bunny struct {
+		Name string
+		Age  int64
+	}
+
+	errNoCarrots := errors.New("no carrots left")
+	db := NewRecordingExecutor().
+		StubRows([]string{"Name", "Age"}, []any{"ollie", int64(2)}, []any{"oliver", int64(1)}).
+		StubErr(errNoCarrots).
+		StubRows([]string{"COUNT"}, []any{int64(2)})
+	defer db.Close()
+
+	bunnies, err := Select[bunny]().Where(LessThan("Age", 3)).Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", 2}, {"oliver", 1}}, bunnies)
+
+	_, err = Delete[bunny]().Where(Equal("Name", "ollie")).Exec(db)
+	assert.ErrorIs(t, err, errNoCarrots)
+
+	count, err := Select[bunny]().CountDistinct("Name", db)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	// Once the stubs run out, there are no rows.
+	result, err := Update[bunny]().Set("Age", 3).Where(Equal("Name", "oliver")).Exec(db)
+	assert.NoError(t, err)
+	rowsAffected, err := result.RowsAffected()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rowsAffected)
+
+	assert.Equal(
+		t,
+		[]RecordedQuery{
+			{`SELECT "Name", "Age" FROM "bunny" WHERE "Age" < ?;`, []any{3}},
+			{`DELETE FROM "bunny" WHERE "Name" = ?;`, []any{"ollie"}},
+			{`SELECT COUNT(DISTINCT "Name") FROM "bunny";`, nil},
+			{`UPDATE "bunny" SET "Age" = ? WHERE "Name" = ?;`, []any{3, "oliver"}},
+		},
+		db.Queries(),
+	)
+}
+
+func TestRecordingExecutorStubResult(t *testing.T) {
+	type bunny struct {
+		Name string
+		Age  int64
+	}
+
+	db := NewRecordingExecutor().
+		StubResult(2, 42).
+		StubRows([]string{"Name", "Age"}, []any{"ollie", int64(2)}, []any{"oliver"})
+	defer db.Close()
+
+	result, err := Insert[bunny]().Values(bunny{"ollie", 2}, bunny{"oliver", 1}).Exec(db)
+	assert.NoError(t, err)
+	rowsAffected, err := result.RowsAffected()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), rowsAffected)
+	lastInsertID, err := result.LastInsertId()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), lastInsertID)
+
+	// The second row is missing a value, so the query fails, rather than the stub panicking.
+	_, err = Select[bunny]().Query(db)
+	assert.ErrorIs(t, err, ErrStubRowArity{2, 1})
+}
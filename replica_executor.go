@@ -0,0 +1,67 @@
+package qubr
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+)
+
+// ReplicaExecutor is an Executor which sends reads to replicas of the database, and writes to the primary.
+// Queries, from QueryContext and QueryRowContext, are spread across the replicas in turn, while ExecContext always uses
+// the primary. Reads which must see an earlier write, or which lock rows, such as with
+// SelectBuilder.ForUpdateSkipLocked, should use a context from UsePrimary.
+// Example:
+//
+//	db := NewReplicaExecutor(primary, replica1, replica2)
+//
+//	_, err := Insert[User]().Values(user).ExecContext(ctx, db) // Primary.
+//	users, err := Select[User]().QueryContext(ctx, db)         // Replica.
+//	users, err = Select[User]().QueryContext(UsePrimary(ctx), db)
+type ReplicaExecutor struct {
+	primary  Executor
+	replicas []Executor
+
+	next atomic.Uint64
+}
+
+var _ Executor = (*ReplicaExecutor)(nil)
+
+// NewReplicaExecutor will construct a new ReplicaExecutor. If no replicas are given, then everything uses the primary.
+func NewReplicaExecutor(primary Executor, replicas ...Executor) *ReplicaExecutor {
+	return &ReplicaExecutor{primary: primary, replicas: replicas}
+}
+
+type usePrimaryKey struct{}
+
+// UsePrimary will return a context which causes a ReplicaExecutor to send queries to the primary, rather than a replica.
+func UsePrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, usePrimaryKey{}, true)
+}
+
+// ExecContext will run ExecContext on the primary.
+func (e *ReplicaExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return e.primary.ExecContext(ctx, query, args...)
+}
+
+// QueryContext will run QueryContext on the next replica, or the primary, if the context is from UsePrimary.
+func (e *ReplicaExecutor) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return e.reader(ctx).QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext will run QueryRowContext on the next replica, or the primary, if the context is from UsePrimary.
+func (e *ReplicaExecutor) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return e.reader(ctx).QueryRowContext(ctx, query, args...)
+}
+
+// reader is the Executor which the next read is sent to.
+func (e *ReplicaExecutor) reader(ctx context.Context) Executor {
+	if len(e.replicas) == 0 {
+		return e.primary
+	}
+	if usePrimary, _ := ctx.Value(usePrimaryKey{}).(bool); usePrimary {
+		return e.primary
+	}
+
+	// Subtracting one, so the first replica is used first.
+	return e.replicas[(e.next.Add(1)-1)%uint64(len(e.replicas))]
+}
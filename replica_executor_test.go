@@ -0,0 +1,52 @@
+package qubr
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestReplicaExecutor(t *testing.T) {
+	type bunny struct {
+		Name string
+	}
+
+	setup := `CREATE TABLE "bunny" ("Name" TEXT);`
+	primary := SetupTestDatabase(t, setup)
+	replica1 := SetupTestDatabase(t, setup, `INSERT INTO "bunny" VALUES('ollie')`)
+	replica2 := SetupTestDatabase(t, setup, `INSERT INTO "bunny" VALUES('oliver')`)
+
+	db := NewReplicaExecutor(primary, replica1, replica2)
+
+	_, err := Insert[bunny]().Values(bunny{"king ollie"}).Exec(db)
+	assert.NoError(t, err)
+
+	// Each read goes to the next replica in turn.
+	var names []string
+	for range 3 {
+		b, err := Select[bunny]().GetOne(db)
+		assert.NoError(t, err)
+		names = append(names, b.Name)
+	}
+	assert.Equal(t, []string{"ollie", "oliver", "ollie"}, names)
+
+	// Reading our own write requires the primary.
+	bunnies, err := Select[bunny]().QueryContext(UsePrimary(context.Background()), db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"king ollie"}}, bunnies)
+}
+
+func TestReplicaExecutorWithoutReplicas(t *testing.T) {
+	type bunny struct {
+		Name string
+	}
+
+	db := NewReplicaExecutor(SetupTestDatabase(t, `CREATE TABLE "bunny" ("Name" TEXT);`))
+
+	_, err := Insert[bunny]().Values(bunny{"ollie"}).Exec(db)
+	assert.NoError(t, err)
+
+	bunnies, err := Select[bunny]().Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie"}}, bunnies)
+}
@@ -0,0 +1,18 @@
+package qubr
+
+import "sync/atomic"
+
+var safeMode atomic.Bool
+
+// SetSafeMode will enable or disable safe mode for every builder. While enabled, methods which place raw SQL into a
+// query return ErrRawInSafeMode when building, so only placeholders are used for input. These methods are:
+//
+//   - SelectBuilder.AndRaw, SelectBuilder.OrRaw, and SelectBuilder.ColumnRaw
+//   - UpdateBuilder.AndRaw and UpdateBuilder.OrRaw
+//   - DeleteBuilder.AndRaw and DeleteBuilder.OrRaw
+//
+// These are also caught within the builder of any Subquery the query uses, such as with In or UpdateBuilder.SetSubquery.
+// Safe mode can also be enabled for a single builder, using its SafeMode method.
+func SetSafeMode(enabled bool) {
+	safeMode.Store(enabled)
+}
@@ -0,0 +1,137 @@
+package qubr
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSafeMode(t *testing.T) {
+	type bunny struct {
+		Name string
+		Age  int64
+	}
+
+	tests := []struct {
+		name    string
+		builder QueryBuilder
+		wantErr error
+	}{
+		{
+			name:    "select without raw",
+			builder: Select[bunny]().Where(Equal("Name", "ollie")).SafeMode(),
+		},
+		{
+			name:    "select with raw condition",
+			builder: Select[bunny]().SafeMode().Where(Equal("Name", "ollie")).AndRaw(`"Age" % 2 = 0`),
+			wantErr: ErrRawInSafeMode,
+		},
+		{
+			name:    "select with raw column",
+			builder: Select[bunny]().ColumnRaw(`"Age" * 12`, "Age").SafeMode(),
+			wantErr: ErrRawInSafeMode,
+		},
+		{
+			name:    "update with raw condition",
+			builder: Update[bunny]().Set("Age", 3).SafeMode().Where(Equal("Name", "ollie")).OrRaw("1=1"),
+			wantErr: ErrRawInSafeMode,
+		},
+		{
+			name:    "update without raw",
+			builder: Update[bunny]().Set("Age", 3).Where(Equal("Name", "ollie")).SafeMode(),
+		},
+		{
+			name: "select with raw within subquery",
+			builder: Select[bunny]().
+				Where(In("Name", Select[bunny]().WithFields("Name").Where(Equal("Age", 2)).OrRaw("1=1"))).
+				SafeMode(),
+			wantErr: ErrRawInSafeMode,
+		},
+		{
+			name: "select with raw column within subquery",
+			builder: Select[bunny]().
+				Where(GreaterThan("Age", ScalarSubquery(Select[bunny]().ColumnRaw(`MAX("Age")`, "Age")))).
+				SafeMode(),
+			wantErr: ErrRawInSafeMode,
+		},
+		{
+			name: "select with subquery without raw",
+			builder: Select[bunny]().
+				Where(In("Name", Select[bunny]().WithFields("Name").Where(Equal("Age", 2)))).
+				SafeMode(),
+		},
+		{
+			name: "update with raw within set subquery",
+			builder: Update[bunny]().
+				SetSubquery("Age", Select[bunny]().ColumnRaw(`MAX("Age")`, "Age")).
+				Where(Equal("Name", "ollie")).
+				SafeMode(),
+			wantErr: ErrRawInSafeMode,
+		},
+		{
+			name: "delete with raw within subquery",
+			builder: Delete[bunny]().
+				Where(NotIn("Name", Select[bunny]().WithFields("Name").Where(Equal("Age", 2)).AndRaw("1=1"))).
+				SafeMode(),
+			wantErr: ErrRawInSafeMode,
+		},
+		{
+			name:    "delete with raw condition",
+			builder: Delete[bunny]().Where(Equal("Name", "ollie")).OrRaw(`"Age" > 200`).SafeMode(),
+			wantErr: ErrRawInSafeMode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.builder.BuildQuery()
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSetSafeMode(t *testing.T) {
+	type bunny struct {
+		Name string
+		Age  int64
+	}
+
+	SetSafeMode(true)
+	t.Cleanup(func() { SetSafeMode(false) })
+
+	_, _, err := Select[bunny]().Where(Equal("Name", "ollie")).OrRaw(`"Age" > 200`).BuildQuery()
+	assert.ErrorIs(t, err, ErrRawInSafeMode)
+
+	_, _, err = Update[bunny]().Set("Age", 3).Where(Equal("Name", "ollie")).OrRaw("1=1").BuildQuery()
+	assert.ErrorIs(t, err, ErrRawInSafeMode)
+
+	_, _, err = Delete[bunny]().Where(Equal("Name", "ollie")).BuildQuery()
+	assert.NoError(t, err)
+}
+
+func TestSafeModeBeforeCounting(t *testing.T) {
+	type bunny struct {
+		Name string
+		Age  int64
+	}
+
+	db := NewRecordingExecutor()
+	defer db.Close()
+
+	builder := Select[bunny]().Where(Equal("Name", "ollie")).OrRaw("1=1").SafeMode()
+
+	_, err := builder.Paginate(db, 1, 10)
+	assert.ErrorIs(t, err, ErrRawInSafeMode)
+
+	_, err = builder.CountDistinct("Name", db)
+	assert.ErrorIs(t, err, ErrRawInSafeMode)
+
+	type secretBunny struct {
+		Name   string
+		secret string
+	}
+
+	_, err = Select[secretBunny]().StrictExportedFields().Paginate(db, 1, 10)
+	assert.ErrorIs(t, err, ErrUnexportedField{"secret"})
+
+	// Nothing should have been run, not even the count.
+	assert.Empty(t, db.Queries())
+}
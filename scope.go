@@ -0,0 +1,42 @@
+package qubr
+
+import (
+	"reflect"
+	"sync"
+)
+
+// scopeKey identifies a registered scope, since the same name can be registered for many types.
+type scopeKey struct {
+	forType reflect.Type
+	name    string
+}
+
+var (
+	scopesMu sync.RWMutex
+	scopes   = map[scopeKey]any{}
+)
+
+// RegisterScope will register a named function, which is applied to a SelectBuilder of T by SelectBuilder.Scope.
+// This is useful for sharing common filters, such as only selecting active users, across many queries.
+// Registering a name which is already registered for T will replace it. Within fn, And and Or can be used whether or
+// not the builder already has a where clause, see SelectBuilder.Scope.
+// Example:
+//
+//	RegisterScope("active", func(b SelectBuilder[User]) SelectBuilder[User] {
+//		return b.And(IsTrue("Active"))
+//	})
+func RegisterScope[T any](name string, fn func(b SelectBuilder[T]) SelectBuilder[T]) {
+	scopesMu.Lock()
+	defer scopesMu.Unlock()
+
+	scopes[scopeKey{reflect.TypeFor[T](), name}] = fn
+}
+
+// lookupScope will find the scope registered for T with the name. If there is none, then ok is false.
+func lookupScope[T any](name string) (fn func(b SelectBuilder[T]) SelectBuilder[T], ok bool) {
+	scopesMu.RLock()
+	defer scopesMu.RUnlock()
+
+	fn, ok = scopes[scopeKey{reflect.TypeFor[T](), name}].(func(b SelectBuilder[T]) SelectBuilder[T])
+	return fn, ok
+}
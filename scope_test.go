@@ -0,0 +1,90 @@
+package qubr
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSelectScope(t *testing.T) {
+	type scopedBunny struct {
+		Name string
+		Age  int64
+		Fed  bool
+	}
+
+	RegisterScope("hungry", func(b SelectBuilder[scopedBunny]) SelectBuilder[scopedBunny] {
+		return b.Where(IsFalse("Fed"))
+	})
+	RegisterScope("young", func(b SelectBuilder[scopedBunny]) SelectBuilder[scopedBunny] {
+		return b.And(LessThan("Age", 2))
+	})
+
+	query, args, err := Select[scopedBunny]().
+		Scope("hungry").
+		Scope("young").
+		Or(Equal("Name", "ollie")).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Name", "Age", "Fed" FROM "scopedBunny" WHERE "Fed" = ? AND "Age" < ? OR "Name" = ?;`, query)
+	assert.Equal(t, []any{false, 2, "ollie"}, args)
+}
+
+func TestSelectScopeWithoutWhere(t *testing.T) {
+	type activeBunny struct {
+		Name   string
+		Active bool
+	}
+
+	RegisterScope("active", func(b SelectBuilder[activeBunny]) SelectBuilder[activeBunny] {
+		return b.And(IsTrue("Active"))
+	})
+
+	query, args, err := Select[activeBunny]().Scope("active").BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Name", "Active" FROM "activeBunny" WHERE "Active" = ?;`, query)
+	assert.Equal(t, []any{true}, args)
+
+	// Outside of a scope, And still needs a where clause first.
+	_, _, err = Select[activeBunny]().Scope("active").NoWhere().And(IsTrue("Active")).BuildQuery()
+	assert.ErrorIs(t, err, ErrMissingWhereClause)
+}
+
+func TestSelectNestedScope(t *testing.T) {
+	type nestedBunny struct {
+		Name   string
+		Active bool
+	}
+
+	RegisterScope("named", func(b SelectBuilder[nestedBunny]) SelectBuilder[nestedBunny] {
+		return b.OrderBy("Name", Ascending)
+	})
+	RegisterScope("named and active", func(b SelectBuilder[nestedBunny]) SelectBuilder[nestedBunny] {
+		// Still within this scope after the inner one, so And can start the where clause.
+		return b.Scope("named").And(IsTrue("Active"))
+	})
+
+	query, args, err := Select[nestedBunny]().Scope("named and active").BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Name", "Active" FROM "nestedBunny" WHERE "Active" = ? ORDER BY "Name" ASC;`, query)
+	assert.Equal(t, []any{true}, args)
+
+	// Once the outer scope is done, And needs a where clause first again.
+	_, _, err = Select[nestedBunny]().Scope("named and active").NoWhere().And(IsTrue("Active")).BuildQuery()
+	assert.ErrorIs(t, err, ErrMissingWhereClause)
+}
+
+func TestSelectUnknownScope(t *testing.T) {
+	type scopedCarrot struct {
+		Name string
+	}
+
+	// Registered for another type, which is not the same scope.
+	RegisterScope("crunchy", func(b SelectBuilder[struct{ Name string }]) SelectBuilder[struct{ Name string }] {
+		return b
+	})
+
+	_, _, err := Select[scopedCarrot]().Scope("crunchy").BuildQuery()
+
+	assert.ErrorIs(t, err, ErrUnknownScope{"crunchy"})
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"net/url"
 	"reflect"
+	"slices"
 	"strings"
+	"time"
 )
 
 // SelectBuilder is a QueryBuilder for building SQL SELECT queries.
@@ -20,11 +23,42 @@ import (
 //	}
 type SelectBuilder[T any] struct {
 	from         tableName
+	alias        string
 	selectFields *[]string
+	columns      []aliasedColumn
+	joins        []join
 
 	fieldOperationTree fieldOperationTree
+	inScope            bool // Whether a scope is being applied, where And and Or can start the where clause.
 
-	limit *uint64
+	orderBy []orderBy
+
+	limit      *uint64
+	maxLimit   *uint64
+	offset     *uint64
+	fetchFirst bool
+
+	intoTable *tableName
+
+	forUpdateSkipLocked bool
+
+	qualifyColumns bool
+
+	timeFormat    TimeFormat
+	columnMatch   ColumnMatch
+	strictColumns bool
+
+	comment string
+
+	readHint string
+
+	pretty bool
+
+	timeout time.Duration
+
+	strictExportedFields bool
+
+	safeMode bool
 
 	err error
 }
@@ -52,18 +86,109 @@ func (b SelectBuilder[T]) From(tableName string) SelectBuilder[T] {
 	return b
 }
 
+// ColumnRaw will select a column of raw SQL, such as an aggregate or window function, named by alias.
+// Since rows are mapped to T by column name, the result is scanned into the field of T which alias matches. If the alias
+// matches a column already being selected, the raw column takes its place, otherwise, it is added after the others.
+// The expression is placed into the query as it is, so it must never contain untrusted input.
+// Equivalent SQL will be:
+//
+//	SELECT ..., ROW_NUMBER() OVER (PARTITION BY "Farm" ORDER BY "Age") AS "alias" FROM ...
+func (b SelectBuilder[T]) ColumnRaw(expr string, alias string) SelectBuilder[T] {
+	column, err := newRawColumn(expr, alias)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	b.columns = append(slices.Clip(b.columns), column)
+	return b
+}
+
+// CaseColumn will select a column of a Case expression, named by alias. The conditions and results of the Case are
+// placeholders, and their args come before those of the where clause. Like SelectBuilder.ColumnRaw, the result is
+// scanned into the field of T which alias matches, and takes the place of the column already selected for it.
+// Equivalent SQL will be:
+//
+//	SELECT ..., CASE WHEN "field" >= ? THEN ? ELSE ? END AS "alias" FROM ...
+func (b SelectBuilder[T]) CaseColumn(c Case, alias string) SelectBuilder[T] {
+	if alias == "" || strings.Contains(alias, `"`) {
+		b.err = ErrInvalidColumnName{alias}
+		return b
+	}
+
+	expr, args, err := c.mapFieldNames(fieldColumnName[T]).queryData()
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	b.columns = append(slices.Clip(b.columns), aliasedColumn{expr: expr, args: args, alias: alias})
+	return b
+}
+
+// JoinUsing will join another table onto the select, on the columns which the tables share the names of.
+// At least one column must be provided.
+// Equivalent SQL will be:
+//
+//	JOIN "table" USING ("column1", "column2")
+func (b SelectBuilder[T]) JoinUsing(tableName string, columns ...string) SelectBuilder[T] {
+	if len(columns) == 0 {
+		b.err = ErrNoJoinColumns
+		return b
+	}
+	for _, column := range columns {
+		if column == "" || strings.Contains(column, `"`) {
+			b.err = ErrInvalidColumnName{column}
+			return b
+		}
+	}
+
+	t, err := newTableNameFromString(tableName)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	b.joins = append(slices.Clip(b.joins), join{kind: joinKindUsing, table: *t, columns: columns})
+	return b
+}
+
+// NaturalJoin will join another table onto the select, on all columns which the tables share the names of.
+// Equivalent SQL will be:
+//
+//	NATURAL JOIN "table"
+func (b SelectBuilder[T]) NaturalJoin(tableName string) SelectBuilder[T] {
+	t, err := newTableNameFromString(tableName)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	b.joins = append(slices.Clip(b.joins), join{kind: joinKindNatural, table: *t})
+	return b
+}
+
 // WithFields allows the selection of very specific fields, instead of all fields in the struct.
 // The field needs to exist on the struct, and it has to be the name we will use in the query.
+// This is the only way to select a lazy field, which has the "lazy" option in its "db" tag, such as `db:"photo,lazy"`.
 func (b SelectBuilder[T]) WithFields(names ...string) SelectBuilder[T] {
 	selectType := reflect.TypeFor[T]()
+	if err := checkStructType(selectType); err != nil {
+		b.err = err
+		return b
+	}
+
+	names = slices.Clone(names)
 
 nameExists:
-	for _, name := range names {
+	for i, name := range names {
+		names[i] = fieldColumnName[T](name)
+
 		// Iterate over the fields in the struct for each of the names, checking if the "structFieldName" results in
 		// the name provided. If not, then this name cannot be used.
-		for i := range selectType.NumField() {
-			if structFieldName(selectType.Field(i)) == name {
-				break nameExists
+		for j := range selectType.NumField() {
+			if structFieldName(selectType.Field(j)) == names[i] {
+				continue nameExists
 			}
 		}
 
@@ -78,21 +203,60 @@ nameExists:
 // Where will apply a FieldOperation as the initial comparison operator of a where clause.
 // Where cannot be called more than once, use SelectBuilder.And or SelectBuilder.Or for further filtering.
 func (b SelectBuilder[T]) Where(op FieldOperation) SelectBuilder[T] {
-	if b.fieldOperationTree != emptyFieldOperationTree {
+	if !b.fieldOperationTree.isEmpty() {
+		b.err = ErrDoubleWhereClause
+		return b
+	}
+
+	b.fieldOperationTree = fieldOperationTree{entries: []fieldOperationEntry{{op: op}}}
+
+	return b
+}
+
+// WhereExample will apply an Equal for each exported field of the example, which is not the zero value for its type,
+// as the initial conditions of a where clause. These are all joined with AND.
+// Since zero values are skipped, this cannot be used to match on a zero value. An example with only zero values will
+// result in ErrEmptyExample.
+// Like SelectBuilder.Where, this cannot be called more than once, or along with SelectBuilder.Where. Though, further
+// conditions can be added with SelectBuilder.And and SelectBuilder.Or, which extend the conditions of the example.
+func (b SelectBuilder[T]) WhereExample(example T) SelectBuilder[T] {
+	if !b.fieldOperationTree.isEmpty() {
 		b.err = ErrDoubleWhereClause
 		return b
 	}
 
-	b.fieldOperationTree.op = op
+	tree, err := newExampleFieldOperationTree(example)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	b.fieldOperationTree = tree
+	return b
+}
+
+// WhereGroup will apply a Group as the initial condition of a where clause, built using fn.
+// Like SelectBuilder.Where, this cannot be called more than once, or along with SelectBuilder.Where.
+func (b SelectBuilder[T]) WhereGroup(fn func(g *Group)) SelectBuilder[T] {
+	if !b.fieldOperationTree.isEmpty() {
+		b.err = ErrDoubleWhereClause
+		return b
+	}
+
+	group, err := newFieldOperationGroup(fn)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	b.fieldOperationTree = fieldOperationTree{entries: []fieldOperationEntry{{group: group}}}
 
 	return b
 }
 
 // And will apply an AND to the existing where clause. SelectBuilder.Where must be called before this.
 func (b SelectBuilder[T]) And(op FieldOperation) SelectBuilder[T] {
-	err := appendToFieldOperationTree(&b.fieldOperationTree, func(next *fieldOperationTree) {
-		next.and = &fieldOperationTree{op: op}
-	})
+	err := b.appendWhere(fieldOperationEntry{connector: connectorAnd, op: op})
 	if err != nil {
 		b.err = err
 	}
@@ -101,15 +265,138 @@ func (b SelectBuilder[T]) And(op FieldOperation) SelectBuilder[T] {
 
 // Or will apply an OR to the existing where clause. SelectBuilder.Where must be called before this.
 func (b SelectBuilder[T]) Or(op FieldOperation) SelectBuilder[T] {
-	err := appendToFieldOperationTree(&b.fieldOperationTree, func(next *fieldOperationTree) {
-		next.or = &fieldOperationTree{op: op}
-	})
+	err := b.appendWhere(fieldOperationEntry{connector: connectorOr, op: op})
+	if err != nil {
+		b.err = err
+	}
+	return b
+}
+
+// AndRaw will apply an AND to the existing where clause, with a condition of raw SQL, and the args for any of its
+// placeholders. The condition is rendered within parentheses. SelectBuilder.Where must be called before this.
+// The expression is placed into the query as it is, so it must never contain untrusted input. Instead, use
+// placeholders, and pass the input as args.
+func (b SelectBuilder[T]) AndRaw(expr string, args ...any) SelectBuilder[T] {
+	raw, err := newRawCondition(expr, args)
+	if err == nil {
+		err = b.appendWhere(fieldOperationEntry{connector: connectorAnd, raw: raw})
+	}
+	if err != nil {
+		b.err = err
+	}
+	return b
+}
+
+// OrRaw will apply an OR to the existing where clause, with a condition of raw SQL, and the args for any of its
+// placeholders. The condition is rendered within parentheses. SelectBuilder.Where must be called before this.
+// Like SelectBuilder.AndRaw, the expression must never contain untrusted input.
+func (b SelectBuilder[T]) OrRaw(expr string, args ...any) SelectBuilder[T] {
+	raw, err := newRawCondition(expr, args)
+	if err == nil {
+		err = b.appendWhere(fieldOperationEntry{connector: connectorOr, raw: raw})
+	}
+	if err != nil {
+		b.err = err
+	}
+	return b
+}
+
+// WhereFilter will apply an AND to the where clause for each of the conditions of the Filter, from the values given,
+// such as the query params of a request. If there is no where clause yet, then the first condition starts it, so this
+// can be used with or without SelectBuilder.Where. If none of the params of the Filter are in values, this does nothing.
+// See Filter.Conditions for how the values are converted.
+func (b SelectBuilder[T]) WhereFilter(f Filter, values url.Values) SelectBuilder[T] {
+	ops, err := f.Conditions(values)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	for _, op := range ops {
+		if b.fieldOperationTree.isEmpty() {
+			b = b.Where(op)
+			continue
+		}
+		b = b.And(op)
+	}
+
+	return b
+}
+
+// NoWhere will clear any where clause that has been applied, so that a new one can be applied using SelectBuilder.Where.
+// This is useful for deriving an unfiltered builder from one with filters. If there is no where clause, this does
+// nothing.
+func (b SelectBuilder[T]) NoWhere() SelectBuilder[T] {
+	b.fieldOperationTree = fieldOperationTree{}
+	return b
+}
+
+// AndGroup will apply an AND to the existing where clause, with a Group built using fn.
+// The Group will be rendered within parentheses. SelectBuilder.Where must be called before this.
+func (b SelectBuilder[T]) AndGroup(fn func(g *Group)) SelectBuilder[T] {
+	group, err := newFieldOperationGroup(fn)
+	if err == nil {
+		err = b.appendWhere(fieldOperationEntry{connector: connectorAnd, group: group})
+	}
+	if err != nil {
+		b.err = err
+	}
+	return b
+}
+
+// OrGroup will apply an OR to the existing where clause, with a Group built using fn.
+// The Group will be rendered within parentheses. SelectBuilder.Where must be called before this.
+func (b SelectBuilder[T]) OrGroup(fn func(g *Group)) SelectBuilder[T] {
+	group, err := newFieldOperationGroup(fn)
+	if err == nil {
+		err = b.appendWhere(fieldOperationEntry{connector: connectorOr, group: group})
+	}
 	if err != nil {
 		b.err = err
 	}
 	return b
 }
 
+// Scope will apply the function registered for T with the name, using RegisterScope. Scopes are applied in the order they
+// are called, like any other method of SelectBuilder, so they can be combined with each other and with other filters.
+// Within the scope, SelectBuilder.And, SelectBuilder.Or, and their Raw and Group forms will start the where clause if
+// there is none yet, so scopes should use these rather than SelectBuilder.Where.
+// If no scope is registered with the name, then ErrUnknownScope is returned when building.
+func (b SelectBuilder[T]) Scope(name string) SelectBuilder[T] {
+	fn, ok := lookupScope[T](name)
+	if !ok {
+		b.err = ErrUnknownScope{name}
+		return b
+	}
+
+	// Scopes may apply other scopes, so the outer scope is still within one once the inner scope is done.
+	prev := b.inScope
+	b.inScope = true
+	b = fn(b)
+	b.inScope = prev
+	return b
+}
+
+// appendWhere will append the entry to the where clause. Within a scope, the entry will start the where clause if there
+// is none, so that a scope does not need to know whether it is the first to filter.
+func (b *SelectBuilder[T]) appendWhere(entry fieldOperationEntry) error {
+	if b.inScope && b.fieldOperationTree.isEmpty() {
+		b.fieldOperationTree = fieldOperationTree{entries: []fieldOperationEntry{entry}}
+		return nil
+	}
+	return appendToFieldOperationTree(&b.fieldOperationTree, entry)
+}
+
+// OrderBy will sort the results of the select statement by the field, in the direction given.
+// Each call adds another field to sort by, which is used when the fields before it are equal.
+// Equivalent SQL will be:
+//
+//	SELECT ... ORDER BY "field1" ASC, "field2" DESC
+func (b SelectBuilder[T]) OrderBy(field string, direction SortDirection) SelectBuilder[T] {
+	b.orderBy = append(slices.Clip(b.orderBy), orderBy{field, direction})
+	return b
+}
+
 // Limit will apply a limit to the select statement. Limiting the number of rows resulting from your table.
 // This cannot be called more than once.
 func (b SelectBuilder[T]) Limit(n uint64) SelectBuilder[T] {
@@ -124,99 +411,564 @@ func (b SelectBuilder[T]) Limit(n uint64) SelectBuilder[T] {
 	return b
 }
 
+// MaxLimit will cap the limit of the select statement at n. A greater limit given to SelectBuilder.Limit is reduced to
+// n, rather than resulting in an error, and if no limit is given, then n is applied. The page size of
+// SelectBuilder.Paginate is capped the same way. This is useful for guarding endpoints where the limit comes from a
+// client.
+// Equivalent SQL will be:
+//
+//	SELECT ... LIMIT n;
+func (b SelectBuilder[T]) MaxLimit(n uint64) SelectBuilder[T] {
+	b.maxLimit = &n
+	return b
+}
+
+// cappedLimit will determine the limit of the select statement, after applying SelectBuilder.MaxLimit.
+func (b SelectBuilder[T]) cappedLimit() *uint64 {
+	if b.maxLimit != nil && (b.limit == nil || *b.limit > *b.maxLimit) {
+		return b.maxLimit
+	}
+	return b.limit
+}
+
+// Offset will apply an offset to the select statement. Skipping over the first n rows resulting from your table.
+// This cannot be called more than once.
+func (b SelectBuilder[T]) Offset(n uint64) SelectBuilder[T] {
+	if b.offset != nil {
+		b.err = ErrOffsetAlreadySet
+		return b
+	}
+
+	b.offset = &n
+	return b
+}
+
+// IntoTable will materialize the results of the select into a new table, instead of returning them.
+// This cannot be called more than once, and the resulting query should be run with SelectBuilder.Exec.
+//
+// There is no single syntax for this across engines. SQL Server uses the "SELECT ... INTO" form, whereas SQLite and
+// MySQL only understand "CREATE TABLE ... AS SELECT". Postgres supports both, so qubr will always produce the latter:
+//
+//	CREATE TABLE "backup" AS SELECT "field1", "field2" FROM "table";
+func (b SelectBuilder[T]) IntoTable(tableName string) SelectBuilder[T] {
+	if b.intoTable != nil {
+		b.err = ErrTableNameAlreadySet
+		return b
+	}
+
+	t, err := newTableNameFromString(tableName)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	b.intoTable = t
+	return b
+}
+
+// MatchColumns will set how the columns of the resulting rows are matched to the fields of T.
+// By default, this is ColumnMatchExact.
+func (b SelectBuilder[T]) MatchColumns(m ColumnMatch) SelectBuilder[T] {
+	b.columnMatch = m
+	return b
+}
+
+// StrictColumns will cause an ErrColumnMismatch when mapping rows, if any column of the result does not match a field
+// of T, or any exported field of T does not match a column. By default, columns without a field are discarded, and
+// fields without a column are left as the zero value.
+func (b SelectBuilder[T]) StrictColumns() SelectBuilder[T] {
+	b.strictColumns = true
+	return b
+}
+
+// StoreTimeAs will set the TimeFormat which time.Time fields are parsed from, and which time.Time values in the where
+// clause are compared as. By default, this is TimeFormatNative.
+func (b SelectBuilder[T]) StoreTimeAs(f TimeFormat) SelectBuilder[T] {
+	b.timeFormat = f
+	return b
+}
+
+// FetchFirst will render the limit and offset in the form of the SQL standard, rather than with LIMIT. The offset comes
+// first, so its arg comes before that of the limit.
+// This is supported by Postgres, SQL Server 2012 and above, DB2, and Oracle 12c and above. SQLite and MySQL only
+// support LIMIT, and will reject this.
+// Equivalent SQL will be:
+//
+//	SELECT ... OFFSET ? ROWS FETCH FIRST ? ROWS ONLY
+func (b SelectBuilder[T]) FetchFirst() SelectBuilder[T] {
+	b.fetchFirst = true
+	return b
+}
+
+// ForUpdateSkipLocked will lock the selected rows for the rest of the transaction, skipping over any rows which are
+// already locked by another transaction. Along with SelectBuilder.Limit, this can be used to claim rows, such as
+// jobs in a queue, so the query should be run within a transaction, using an sql.Tx as the Executor.
+// This is supported by Postgres, and MySQL 8 and above. SQLite has no row locking, and will reject this.
+// Equivalent SQL will be:
+//
+//	SELECT ... FOR UPDATE SKIP LOCKED
+func (b SelectBuilder[T]) ForUpdateSkipLocked() SelectBuilder[T] {
+	b.forUpdateSkipLocked = true
+	return b
+}
+
+// As will give the table an alias, which is needed when the same table is involved more than once, such as in a
+// self-join. If columns are qualified, using SelectBuilder.QualifyColumns, then the alias is used instead of the
+// table name.
+// Equivalent SQL will be:
+//
+//	SELECT ... FROM "table" AS "alias"
+func (b SelectBuilder[T]) As(alias string) SelectBuilder[T] {
+	if alias == "" || strings.ContainsAny(alias, `".`) {
+		b.err = ErrInvalidTableName{alias}
+		return b
+	}
+
+	b.alias = alias
+	return b
+}
+
+// QualifyColumns will prefix each of the selected fields with the table name, or its alias, which avoids any ambiguity
+// when more than one table is involved. The rows are still mapped to T the same way.
+// Equivalent SQL will be:
+//
+//	SELECT "table"."field1", "table"."field2" FROM "table"
+func (b SelectBuilder[T]) QualifyColumns() SelectBuilder[T] {
+	b.qualifyColumns = true
+	return b
+}
+
+// WithComment will place a comment at the start of the query, which is useful for tracing queries back to the code
+// which ran them, such as in slow query logs. Anything which would close the comment early is removed.
+// Equivalent SQL will be:
+//
+//	/* comment */ ...
+func (b SelectBuilder[T]) WithComment(comment string) SelectBuilder[T] {
+	b.comment = comment
+	return b
+}
+
+// ReadHint will place an optimizer hint comment directly after the SELECT keyword, such as to tolerate stale reads, or
+// to bound the execution time. By default, there is no hint. The hint is trusted, and is placed into the query as it
+// is, except that anything which would close the comment early is removed. Databases which do not understand the
+// hint will treat it as a comment.
+// Equivalent SQL will be:
+//
+//	SELECT /*+ hint */ ...
+func (b SelectBuilder[T]) ReadHint(hint string) SelectBuilder[T] {
+	b.readHint = hint
+	return b
+}
+
+// Pretty will place each major clause of the query on its own line, which is easier to read in logs. By default, the
+// query is on a single line. Only the whitespace differs, so the args are the same either way.
+// Equivalent SQL will be:
+//
+//	SELECT "field1", "field2"
+//	FROM "table"
+//	WHERE "field1" = ?
+//	ORDER BY "field2" ASC
+//	LIMIT ?;
+func (b SelectBuilder[T]) Pretty() SelectBuilder[T] {
+	b.pretty = true
+	return b
+}
+
+// Timeout will set a deadline for running the query, when it is run without a context, such as by SelectBuilder.Exec.
+// If a context is given, such as to SelectBuilder.ExecContext, then the timeout is not used.
+func (b SelectBuilder[T]) Timeout(d time.Duration) SelectBuilder[T] {
+	b.timeout = d
+	return b
+}
+
+// StrictExportedFields will cause SelectBuilder.BuildQuery to return an ErrUnexportedField if T has any unexported fields.
+// By default, unexported fields are silently skipped.
+func (b SelectBuilder[T]) StrictExportedFields() SelectBuilder[T] {
+	b.strictExportedFields = true
+	return b
+}
+
+func (b SelectBuilder[T]) scanOptions() scanOptions {
+	opts := scanOptions{timeFormat: b.timeFormat, columnMatch: b.columnMatch, strictColumns: b.strictColumns}
+	if b.selectFields != nil {
+		opts.selectedColumns = *b.selectFields
+	}
+	return opts
+}
+
+// SafeMode will cause SelectBuilder.BuildQuery to return an ErrRawInSafeMode if any method which places raw SQL into the query
+// has been used, regardless of whether it was called before or after this. See SetSafeMode for these methods.
+func (b SelectBuilder[T]) SafeMode() SelectBuilder[T] {
+	b.safeMode = true
+	return b
+}
+
 // BuildQuery will construct the SQL query SelectBuilder is currently representing.
 // User input will utilize placeholders, and the values of the input will be in the 2nd return value, args.
 // If there was an issue in the construction of SelectBuilder, then the 3rd return value, err will not non-nil.
 //
 // The resulting query should look something like:
 //
-//	SELECT "field1", "field2" FROM "schema"."table" WHERE "field1" = ? LIMIT ?;
+//	SELECT "field1", "field2" FROM "schema"."table" WHERE "field1" = ? ORDER BY "field2" ASC LIMIT ? OFFSET ?;
 func (b SelectBuilder[T]) BuildQuery() (query string, args []any, err error) {
-	if b.err != nil {
-		return "", nil, b.err
+	if err := b.check(); err != nil {
+		return "", nil, err
 	}
 
+	tableName := b.fromTable()
+
 	// "X","Y"
-	var fields string
-	{
-		sb := strings.Builder{}
-		if b.selectFields != nil {
-			// Use select fields instead of the fields present directly on the struct.
-			// We have already validated that these exist.
-			for _, name := range *b.selectFields {
-				sb.WriteString(fmt.Sprintf(`"%s", `, name))
-			}
-		} else {
-			// Struct field names are how we determine the select.
-			selectType := reflect.TypeFor[T]()
-			numField := selectType.NumField()
-
-			for i := range numField {
-				f := selectType.Field(i)
-				if !f.IsExported() {
-					continue
-				}
-
-				sb.WriteString(fmt.Sprintf(`"%s", `, structFieldName(f)))
+	columns, args := b.projection()
+	fields := strings.Join(columns, ", ")
+
+	var joins string
+	for _, j := range b.joins {
+		joins += b.clause(j.String())
+	}
+
+	whereClause, whereArgs, err := b.fieldOperationTree.mapFieldNames(fieldColumnName[T]).encodeTimes(b.timeFormat).buildQuery()
+	if err != nil {
+		return "", nil, err
+	}
+	args = append(args, whereArgs...)
+
+	order := orderByClause(b.orderBy, fieldColumnName[T])
+
+	limitValue := b.cappedLimit()
+
+	var limit, offset string
+	if b.fetchFirst {
+		// The standard form has the offset first, so the args are the other way around.
+		if b.offset != nil {
+			offset = " OFFSET ? ROWS"
+			args = append(args, *b.offset)
+		}
+		if limitValue != nil {
+			limit = " FETCH FIRST ? ROWS ONLY"
+			args = append(args, *limitValue)
+		}
+	} else {
+		if limitValue != nil {
+			limit = " LIMIT ?"
+			args = append(args, *limitValue)
+		}
+		if b.offset != nil {
+			offset = " OFFSET ?"
+			args = append(args, *b.offset)
+		}
+	}
+
+	limitOffset := limit + offset
+	if b.fetchFirst {
+		limitOffset = offset + limit
+	}
+
+	var lock string
+	if b.forUpdateSkipLocked {
+		lock = " FOR UPDATE SKIP LOCKED"
+	}
+
+	var createTable string
+	if b.intoTable != nil {
+		createTable = fmt.Sprintf("CREATE TABLE %s AS ", b.intoTable)
+	}
+
+	return fmt.Sprintf(
+		"%s%sSELECT %s%s%s%s%s%s%s%s;",
+		commentPrefix(b.comment), createTable, hintComment(b.readHint), fields,
+		b.clause(" FROM "+tableName), joins, b.clause(whereClause), b.clause(order), b.clause(limitOffset),
+		b.clause(lock),
+	), args, nil
+}
+
+// check will return the first issue with the construction of the SelectBuilder, or with T, which would stop any query
+// from being built. This is shared by every query the SelectBuilder builds, including those used for counting.
+func (b SelectBuilder[T]) check() error {
+	if b.err != nil {
+		return b.err
+	}
+	if err := checkStructType(reflect.TypeFor[T]()); err != nil {
+		return err
+	}
+	if b.strictExportedFields {
+		if err := checkExportedFields(reflect.TypeFor[T]()); err != nil {
+			return err
+		}
+	}
+	if (b.safeMode || safeMode.Load()) && b.hasRaw() {
+		return ErrRawInSafeMode
+	}
+	return checkDuplicateColumns(reflect.TypeFor[T]())
+}
+
+// clause will place the clause on its own line, in place of its leading space, when SelectBuilder.Pretty is used.
+func (b SelectBuilder[T]) clause(s string) string {
+	if !b.pretty || s == "" {
+		return s
+	}
+	return "\n" + strings.TrimPrefix(s, " ")
+}
+
+// numFields will determine the number of fields the select will result in.
+func (b SelectBuilder[T]) numFields() int {
+	columns, _ := b.projection()
+	return len(columns)
+}
+
+func (b SelectBuilder[T]) hasRawColumn() bool {
+	return slices.ContainsFunc(b.columns, func(c aliasedColumn) bool { return c.raw })
+}
+
+// hasRaw will report whether the select has any raw SQL, within its conditions or its columns.
+func (b SelectBuilder[T]) hasRaw() bool {
+	return b.fieldOperationTree.hasRaw() || b.hasRawColumn()
+}
+
+// fromTable is the table as it is rendered in the FROM clause, along with its alias, if it has one.
+func (b SelectBuilder[T]) fromTable() string {
+	if b.alias != "" {
+		return fmt.Sprintf(`%s AS "%s"`, b.from, b.alias)
+	}
+	return b.from.String()
+}
+
+// qualifier is what columns are prefixed with, when they are qualified.
+func (b SelectBuilder[T]) qualifier() string {
+	if b.alias != "" {
+		return `"` + b.alias + `"`
+	}
+	return b.from.String()
+}
+
+// projection is each of the columns being selected, as they are rendered in the query, along with the args of any
+// aliased columns, in the same order.
+func (b SelectBuilder[T]) projection() (columns []string, args []any) {
+	var names []string
+	if b.selectFields != nil {
+		// Use select fields instead of the fields present directly on the struct.
+		// We have already validated that these exist.
+		names = *b.selectFields
+	} else {
+		// Struct field names are how we determine the select, other than lazy fields, which must be asked for.
+		for _, f := range exportedFields(reflect.TypeFor[T]()) {
+			if _, lazy := structFieldOption(f, "lazy"); lazy {
+				continue
 			}
+			names = append(names, structFieldName(f))
+		}
+	}
+
+	var qualifier string
+	if b.qualifyColumns {
+		qualifier = b.qualifier() + "."
+	}
+
+	columns = make([]string, 0, len(names)+len(b.columns))
+	for _, name := range names {
+		// An aliased column takes the place of the column its alias matches, so the field is scanned from it instead.
+		i := slices.IndexFunc(b.columns, func(c aliasedColumn) bool { return c.alias == name })
+		if i >= 0 {
+			columns = append(columns, b.columns[i].String())
+			args = append(args, b.columns[i].args...)
+			continue
 		}
 
-		// We don't strictly know the length of the fields, so we need to trim the last comma.
-		fields = strings.TrimSuffix(sb.String(), ", ")
+		columns = append(columns, fmt.Sprintf(`%s"%s"`, qualifier, name))
+	}
+	for _, c := range b.columns {
+		if !slices.Contains(names, c.alias) {
+			columns = append(columns, c.String())
+			args = append(args, c.args...)
+		}
 	}
 
-	tableName := b.from.String()
+	return columns, args
+}
 
-	whereClause, whereArgs := b.fieldOperationTree.buildQuery()
+// buildCountQuery will construct a query counting the rows SelectBuilder is representing, using countExpr in COUNT.
+// Only the table and where clause are used, as the selected fields, limit, and offset do not change the count.
+//
+// The resulting query should look something like:
+//
+//	SELECT COUNT(*) FROM "schema"."table" WHERE "field1" = ?;
+func (b SelectBuilder[T]) buildCountQuery(countExpr string) (query string, args []any, err error) {
+	if err := b.check(); err != nil {
+		return "", nil, err
+	}
+
+	tableName := b.fromTable()
+
+	var joins string
+	for _, j := range b.joins {
+		joins += j.String()
+	}
+
+	whereClause, whereArgs, err := b.fieldOperationTree.mapFieldNames(fieldColumnName[T]).encodeTimes(b.timeFormat).buildQuery()
+	if err != nil {
+		return "", nil, err
+	}
 	args = append(args, whereArgs...)
 
-	var limit string
-	if b.limit != nil {
-		limit = " LIMIT ?"
-		args = append(args, *b.limit)
+	return fmt.Sprintf(
+		"%sSELECT COUNT(%s) FROM %s%s%s;",
+		commentPrefix(b.comment), countExpr, tableName, joins, whereClause,
+	), args, nil
+}
+
+// Exec wraps SelectBuilder.ExecContext, which will execute the query represented by the SelectBuilder.
+func (b SelectBuilder[T]) Exec(db Executor) (sql.Result, error) {
+	ctx, cancel := timeoutContext(b.timeout)
+	defer cancel()
+
+	return b.ExecContext(ctx, db)
+}
+
+// ExecContext will execute the query represented by the SelectBuilder, without mapping any rows.
+// This is intended to be used along with SelectBuilder.IntoTable, where there are no rows to be returned.
+func (b SelectBuilder[T]) ExecContext(ctx context.Context, db Executor) (sql.Result, error) {
+	query, args, err := b.BuildQuery()
+	if err != nil {
+		return nil, err
 	}
 
-	return fmt.Sprintf("SELECT %s FROM %s%s%s;", fields, tableName, whereClause, limit), args, nil
+	return execContext(ctx, db, query, args)
 }
 
 // Query wraps SelectBuilder.QueryContext, this will use the query represented by SelectBuilder.
 // The row results are all mapped to T.
-func (b SelectBuilder[T]) Query(db *sql.DB) ([]T, error) {
-	return b.QueryContext(context.Background(), db)
+func (b SelectBuilder[T]) Query(db Executor) ([]T, error) {
+	ctx, cancel := timeoutContext(b.timeout)
+	defer cancel()
+
+	return b.QueryContext(ctx, db)
 }
 
-// QueryContext will use the query represented by the SelectBuilder, utilizing the sql.DB provided.
+// QueryContext will use the query represented by the SelectBuilder, utilizing the Executor provided.
 // The results are all mapped to T.
-func (b SelectBuilder[T]) QueryContext(ctx context.Context, db *sql.DB) ([]T, error) {
+func (b SelectBuilder[T]) QueryContext(ctx context.Context, db Executor) ([]T, error) {
 	query, args, err := b.BuildQuery()
 	if err != nil {
 		return nil, err
 	}
 
-	return QueryContext[T](ctx, db, query, args...)
+	return queryContext[T](ctx, db, b.scanOptions(), query, args...)
 }
 
 // GetOne wraps SelectBuilder.GetOneContext, this will use the query represented by SelectBuilder.
 // There is the expectation that at least one result is returned. The first result will be mapped to T.
-func (b SelectBuilder[T]) GetOne(db *sql.DB) (*T, error) {
-	return b.GetOneContext(context.Background(), db)
+func (b SelectBuilder[T]) GetOne(db Executor) (*T, error) {
+	ctx, cancel := timeoutContext(b.timeout)
+	defer cancel()
+
+	return b.GetOneContext(ctx, db)
 }
 
-// GetOneContext will use the query represented by the SelectBuilder, utilizing the sql.DB provided.
+// GetOneContext will use the query represented by the SelectBuilder, utilizing the Executor provided.
 // There is the expectation that at least one result is returned. The first result will be mapped to T.
-func (b SelectBuilder[T]) GetOneContext(ctx context.Context, db *sql.DB) (*T, error) {
-	// Set the limit to 1, so we don't over-query.
-	l := uint64(1)
-	b.limit = &l
+// If no limit has been set, then a limit of 1 is applied, as only the first result is used.
+func (b SelectBuilder[T]) GetOneContext(ctx context.Context, db Executor) (*T, error) {
+	// Set the limit to 1, so we don't over-query, unless the caller has their own.
+	if b.limit == nil {
+		l := uint64(1)
+		b.limit = &l
+	}
 
-	ts, err := b.QueryContext(ctx, db)
+	query, args, err := b.BuildQuery()
 	if err != nil {
 		return nil, err
 	}
 
-	if len(ts) == 0 {
-		return nil, ErrNoRows
+	return queryRowContext[T](ctx, db, b.scanOptions(), query, args...)
+}
+
+// CountDistinct wraps SelectBuilder.CountDistinctContext, this will count using the query represented by SelectBuilder.
+func (b SelectBuilder[T]) CountDistinct(column string, db Executor) (int64, error) {
+	ctx, cancel := timeoutContext(b.timeout)
+	defer cancel()
+
+	return b.CountDistinctContext(ctx, column, db)
+}
+
+// CountDistinctContext will count the distinct values of the column, in the rows matching the where clause of the
+// SelectBuilder, utilizing the Executor provided. If there are no rows, then the count is 0.
+// Equivalent SQL will be:
+//
+//	SELECT COUNT(DISTINCT "column") FROM "table" WHERE ...;
+func (b SelectBuilder[T]) CountDistinctContext(ctx context.Context, column string, db Executor) (int64, error) {
+	if column == "" || strings.Contains(column, `"`) {
+		return 0, ErrInvalidColumnName{column}
+	}
+
+	query, args, err := b.buildCountQuery(fmt.Sprintf(`DISTINCT "%s"`, fieldColumnName[T](column)))
+	if err != nil {
+		return 0, err
+	}
+
+	var count int64
+	if err = scanOneContext(ctx, db, query, args, &count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// PageResult is a single page of rows, as returned by SelectBuilder.Paginate.
+type PageResult[T any] struct {
+	Items []T
+
+	Total      int64
+	TotalPages int64
+
+	Page     uint64
+	PageSize uint64
+}
+
+// Paginate wraps SelectBuilder.PaginateContext, this will use the query represented by SelectBuilder.
+// Page numbers start from 1.
+func (b SelectBuilder[T]) Paginate(db Executor, page, pageSize uint64) (PageResult[T], error) {
+	ctx, cancel := timeoutContext(b.timeout)
+	defer cancel()
+
+	return b.PaginateContext(ctx, db, page, pageSize)
+}
+
+// PaginateContext will use the query represented by the SelectBuilder, utilizing the Executor provided, to query a
+// single page of rows. Page numbers start from 1.
+// Along with the rows, the total number of rows are counted using the same where clause, and this is used to work out
+// the total number of pages.
+func (b SelectBuilder[T]) PaginateContext(ctx context.Context, db Executor, page, pageSize uint64) (PageResult[T], error) {
+	if page == 0 || pageSize == 0 {
+		return PageResult[T]{}, ErrInvalidPage
+	}
+	if b.maxLimit != nil && pageSize > *b.maxLimit {
+		pageSize = *b.maxLimit
+	}
+
+	countQuery, countArgs, err := b.buildCountQuery("*")
+	if err != nil {
+		return PageResult[T]{}, err
+	}
+
+	var total int64
+	if err = scanOneContext(ctx, db, countQuery, countArgs, &total); err != nil {
+		return PageResult[T]{}, err
+	}
+
+	// The page takes priority over any limit or offset which was set before.
+	offset := (page - 1) * pageSize
+	b.limit = &pageSize
+	b.offset = &offset
+
+	items, err := b.QueryContext(ctx, db)
+	if err != nil {
+		return PageResult[T]{}, err
 	}
 
-	return &ts[0], nil
+	return PageResult[T]{
+		Items:      items,
+		Total:      total,
+		TotalPages: (total + int64(pageSize) - 1) / int64(pageSize),
+		Page:       page,
+		PageSize:   pageSize,
+	}, nil
 }
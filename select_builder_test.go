@@ -1,8 +1,11 @@
 package qubr
 
 import (
+	"context"
 	"github.com/stretchr/testify/assert"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestSelectAll(t *testing.T) {
@@ -96,6 +99,13 @@ func TestSelectWithUnknownSelectFields(t *testing.T) {
 		BuildQuery()
 
 	assert.ErrorIs(t, ErrUnknownFieldName{"Sauce"}, err)
+
+	// Every name is checked, not only the first.
+	_, _, err = Select[bunny]().
+		WithFields("Name", "Sauce").
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrUnknownFieldName{"Sauce"}, err)
 }
 
 func TestSelectWithSimpleFilter(t *testing.T) {
@@ -163,6 +173,151 @@ func TestSelectLimitAlreadySet(t *testing.T) {
 	assert.ErrorIs(t, ErrLimitAlreadySet, err)
 }
 
+func TestSelectMaxLimit(t *testing.T) {
+	type donut struct {
+		Filled    bool
+		Sprinkled bool
+	}
+
+	builder := Select[donut]().From("donuts").MaxLimit(100)
+
+	query, args, err := builder.Limit(2938910).BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Filled", "Sprinkled" FROM "donuts" LIMIT ?;`, query)
+	assert.Equal(t, []any{uint64(100)}, args)
+
+	query, args, err = builder.Limit(10).BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Filled", "Sprinkled" FROM "donuts" LIMIT ?;`, query)
+	assert.Equal(t, []any{uint64(10)}, args)
+
+	query, args, err = builder.BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Filled", "Sprinkled" FROM "donuts" LIMIT ?;`, query)
+	assert.Equal(t, []any{uint64(100)}, args)
+}
+
+func TestSelectMaxLimitAndPaginate(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	db := SetupMemoryTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "EarLength" FLOAT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 15)`,
+		`INSERT INTO "bunny" VALUES('oliver', 20)`,
+		`INSERT INTO "bunny" VALUES('king ollie', 30)`,
+	)
+
+	page, err := Select[bunny]().
+		MaxLimit(2).
+		Paginate(db, 1, 1000000)
+
+	assert.NoError(t, err)
+	assert.Equal(t, PageResult[bunny]{
+		Items:      []bunny{{"ollie", 15}, {"oliver", 20}},
+		Total:      3,
+		TotalPages: 2,
+		Page:       1,
+		PageSize:   2,
+	}, page)
+}
+
+func TestSelectWithLikeAndQuery(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	db := SetupMemoryTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "EarLength" FLOAT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 15)`,
+		`INSERT INTO "bunny" VALUES('king ollie', 30)`,
+		`INSERT INTO "bunny" VALUES('tiny', 2)`,
+	)
+
+	builder := Select[bunny]().Where(Like("Name", "%oll%"))
+
+	query, args, err := builder.BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Name", "EarLength" FROM "bunny" WHERE "Name" LIKE ?;`, query)
+	assert.Equal(t, []any{"%oll%"}, args)
+
+	bunnies, err := builder.Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", 15}, {"king ollie", 30}}, bunnies)
+}
+
+func TestSelectWithBetweenAndQuery(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	db := SetupMemoryTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "EarLength" FLOAT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 15)`,
+		`INSERT INTO "bunny" VALUES('king ollie', 30)`,
+		`INSERT INTO "bunny" VALUES('tiny ollie', 2)`,
+	)
+
+	builder := Select[bunny]().
+		Where(Between("EarLength", 2, 15)).
+		And(Like("Name", "%ollie"))
+
+	query, args, err := builder.BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Name", "EarLength" FROM "bunny" WHERE "EarLength" BETWEEN ? AND ? AND "Name" LIKE ?;`, query)
+	assert.Equal(t, []any{2, 15, "%ollie"}, args)
+
+	bunnies, err := builder.Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", 15}, {"tiny ollie", 2}}, bunnies)
+
+	bunnies, err = Select[bunny]().Where(NotBetween("EarLength", 2, 15)).Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"king ollie", 30}}, bunnies)
+}
+
+func TestSelectOrderByAndQuery(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	db := SetupMemoryTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "EarLength" FLOAT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 15)`,
+		`INSERT INTO "bunny" VALUES('king ollie', 30)`,
+		`INSERT INTO "bunny" VALUES('oliver', 15)`,
+		`INSERT INTO "bunny" VALUES('tiny ollie', 2)`,
+	)
+
+	builder := Select[bunny]().
+		Where(GreaterThan("EarLength", 2)).
+		OrderBy("EarLength", Descending).
+		OrderBy("Name", Ascending).
+		Limit(3)
+
+	query, args, err := builder.BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`SELECT "Name", "EarLength" FROM "bunny" WHERE "EarLength" > ? ORDER BY "EarLength" DESC, "Name" ASC LIMIT ?;`,
+		query,
+	)
+	assert.Equal(t, []any{2, uint64(3)}, args)
+
+	bunnies, err := builder.Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"king ollie", 30}, {"oliver", 15}, {"ollie", 15}}, bunnies)
+}
+
 func TestSelectAndQuery(t *testing.T) {
 	type bunny struct {
 		Name      string
@@ -209,3 +364,1155 @@ func TestSelectAndGetOne(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, &bunny{"ollie the omniscient", 25000, false}, longEaredBunny)
 }
+
+func TestSelectIntoTable(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	query, args, err := Select[bunny]().
+		From("bunnies").
+		IntoTable("bunny_backup").
+		Where(GreaterThan("EarLength", 10)).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `CREATE TABLE "bunny_backup" AS SELECT "Name", "EarLength" FROM "bunnies" WHERE "EarLength" > ?;`, query)
+	assert.Equal(t, []any{10}, args)
+}
+
+func TestSelectIntoTableAndExec(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "EarLength" FLOAT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 15)`,
+		`INSERT INTO "bunny" VALUES('king ollie', 30)`,
+	)
+
+	_, err := Select[bunny]().
+		IntoTable("bunny_backup").
+		Where(GreaterThan("EarLength", 20)).
+		Exec(db)
+	assert.NoError(t, err)
+
+	bunnies, err := Select[bunny]().
+		From("bunny_backup").
+		Query(db)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"king ollie", 30}}, bunnies)
+}
+
+func TestSelectStrictExportedFields(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+
+		age int64
+	}
+
+	_, _, err := Select[bunny]().
+		StrictExportedFields().
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrUnexportedField{"age"}, err)
+
+	type exportedBunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	query, _, err := Select[exportedBunny]().
+		StrictExportedFields().
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Name", "EarLength" FROM "exportedBunny";`, query)
+}
+
+func TestSelectLimitAndOffset(t *testing.T) {
+	type donut struct {
+		Filled    bool
+		Sprinkled bool
+	}
+
+	query, args, err := Select[donut]().
+		From("donuts").
+		Limit(10).
+		Offset(20).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Filled", "Sprinkled" FROM "donuts" LIMIT ? OFFSET ?;`, query)
+	assert.Equal(t, []any{uint64(10), uint64(20)}, args)
+}
+
+func TestSelectOffsetAlreadySet(t *testing.T) {
+	type donut struct {
+		Filled    bool
+		Sprinkled bool
+	}
+
+	_, _, err := Select[donut]().
+		Offset(1).
+		Offset(2).
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrOffsetAlreadySet, err)
+}
+
+func TestSelectAndPaginate(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "EarLength" FLOAT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 15)`,
+		`INSERT INTO "bunny" VALUES('oliver', 20)`,
+		`INSERT INTO "bunny" VALUES('king ollie', 30)`,
+		`INSERT INTO "bunny" VALUES('ollie the omniscient', 25000)`,
+		`INSERT INTO "bunny" VALUES('tiny ollie', 2)`,
+	)
+
+	page, err := Select[bunny]().
+		Where(GreaterThan("EarLength", 10)).
+		Paginate(db, 2, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, PageResult[bunny]{
+		Items:      []bunny{{"ollie the omniscient", 25000}},
+		Total:      4,
+		TotalPages: 2,
+		Page:       2,
+		PageSize:   3,
+	}, page)
+}
+
+func TestSelectPaginateInvalidPage(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	_, err := Select[bunny]().
+		Paginate(nil, 0, 10)
+
+	assert.ErrorIs(t, ErrInvalidPage, err)
+}
+
+func TestSelectWithGroupedFilter(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+		AgeMonths uint16
+	}
+
+	query, args, err := Select[bunny]().
+		From("bunnies").
+		WhereGroup(func(g *Group) {
+			g.And(Equal("Name", "ollie")).Or(Equal("Name", "oliver"))
+		}).
+		AndGroup(func(g *Group) {
+			g.And(GreaterThan("EarLength", 10)).
+				OrGroup(func(g *Group) {
+					g.And(LessThan("AgeMonths", 6)).And(NotEqual("EarLength", 0))
+				})
+		}).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`SELECT "Name", "EarLength", "AgeMonths" FROM "bunnies" `+
+			`WHERE ("Name" = ? OR "Name" = ?) AND ("EarLength" > ? OR ("AgeMonths" < ? AND "EarLength" <> ?));`,
+		query,
+	)
+	assert.Equal(t, []any{"ollie", "oliver", 10, 6, 0}, args)
+}
+
+func TestSelectWithEmptyGroup(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	_, _, err := Select[bunny]().
+		Where(Equal("Name", "ollie")).
+		OrGroup(func(g *Group) {}).
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrEmptyGroup, err)
+}
+
+func TestSelectWhereGroupDoubleUp(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	_, _, err := Select[bunny]().
+		Where(Equal("Name", "ollie")).
+		WhereGroup(func(g *Group) { g.And(Equal("Name", "oliver")) }).
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrDoubleWhereClause, err)
+}
+
+func TestSelectJoinUsing(t *testing.T) {
+	type bunny struct {
+		Name       string
+		CarrotName string
+	}
+
+	query, args, err := Select[bunny]().
+		From("bunnies").
+		JoinUsing("carrots", "BunnyID").
+		Where(Equal("Name", "ollie")).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Name", "CarrotName" FROM "bunnies" JOIN "carrots" USING ("BunnyID") WHERE "Name" = ?;`, query)
+	assert.Equal(t, []any{"ollie"}, args)
+}
+
+func TestSelectJoinUsingNoColumns(t *testing.T) {
+	type bunny struct {
+		Name string
+	}
+
+	_, _, err := Select[bunny]().
+		JoinUsing("carrots").
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrNoJoinColumns, err)
+}
+
+func TestSelectJoinUsingInvalidColumn(t *testing.T) {
+	type bunny struct {
+		Name string
+	}
+
+	_, _, err := Select[bunny]().
+		JoinUsing("carrots", "BunnyID", "").
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrInvalidColumnName{""}, err)
+}
+
+func TestSelectNaturalJoinAndQuery(t *testing.T) {
+	type bunny struct {
+		Name       string
+		CarrotName string
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("BunnyID" INT, "Name" TEXT);`,
+		`CREATE TABLE "carrot" ("BunnyID" INT, "CarrotName" TEXT);`,
+		`INSERT INTO "bunny" VALUES(1, 'ollie')`,
+		`INSERT INTO "bunny" VALUES(2, 'oliver')`,
+		`INSERT INTO "carrot" VALUES(2, 'orange crunch')`,
+	)
+
+	bunnies, err := Select[bunny]().
+		NaturalJoin("carrot").
+		Query(db)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"oliver", "orange crunch"}}, bunnies)
+}
+
+func TestSelectWithScalarSubquery(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "EarLength" FLOAT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 15)`,
+		`INSERT INTO "bunny" VALUES('oliver', 20)`,
+		`INSERT INTO "bunny" VALUES('king ollie', 30)`,
+	)
+
+	builder := Select[bunny]().
+		Where(GreaterThan("EarLength", ScalarSubquery(
+			Select[bunny]().WithFields("EarLength").Where(Equal("Name", "ollie")),
+		))).
+		And(NotEqual("Name", "king ollie"))
+
+	query, args, err := builder.BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`SELECT "Name", "EarLength" FROM "bunny" `+
+			`WHERE "EarLength" > (SELECT "EarLength" FROM "bunny" WHERE "Name" = ?) AND "Name" <> ?;`,
+		query,
+	)
+	assert.Equal(t, []any{"ollie", "king ollie"}, args)
+
+	bunnies, err := builder.Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"oliver", 20}}, bunnies)
+}
+
+func TestSelectNotAStruct(t *testing.T) {
+	_, _, err := Select[int]().
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrNotAStruct{reflect.TypeFor[int]()}, err)
+
+	_, _, err = Select[[]string]().
+		WithFields("Name").
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrNotAStruct{reflect.TypeFor[[]string]()}, err)
+}
+
+func TestSelectWhereExampleWithFurtherFilters(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+		Colour    string `db:"colour"`
+	}
+
+	query, args, err := Select[bunny]().
+		WhereExample(bunny{Colour: "white"}).
+		And(In("Name", "ollie", "oliver")).
+		AndGroup(func(g *Group) {
+			g.And(GreaterThan("EarLength", 10)).Or(Equal("EarLength", 0))
+		}).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`SELECT "Name", "EarLength", "colour" FROM "bunny" `+
+			`WHERE "colour" = ? AND "Name" IN (?, ?) AND ("EarLength" > ? OR "EarLength" = ?);`,
+		query,
+	)
+	assert.Equal(t, []any{"white", "ollie", "oliver", 10, 0}, args)
+}
+
+func TestSelectWhereExampleDoubleUp(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	_, _, err := Select[bunny]().
+		Where(Equal("Name", "ollie")).
+		WhereExample(bunny{EarLength: 20}).
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrDoubleWhereClause, err)
+}
+
+func TestSelectWhereExampleAndQuery(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	db := SetupMemoryTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "EarLength" FLOAT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 15)`,
+		`INSERT INTO "bunny" VALUES('ollie', 20)`,
+		`INSERT INTO "bunny" VALUES('oliver', 30)`,
+	)
+
+	bunnies, err := Select[bunny]().
+		WhereExample(bunny{Name: "ollie"}).
+		And(GreaterThan("EarLength", 15)).
+		Query(db)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", 20}}, bunnies)
+}
+
+func TestSelectInSubquery(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+	type carrot struct {
+		BunnyName string
+		Crunchy   bool
+	}
+
+	db := SetupMemoryTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "EarLength" FLOAT);`,
+		`CREATE TABLE "carrot" ("BunnyName" TEXT, "Crunchy" BOOLEAN);`,
+		`INSERT INTO "bunny" VALUES('ollie', 15)`,
+		`INSERT INTO "bunny" VALUES('oliver', 20)`,
+		`INSERT INTO "carrot" VALUES('oliver', TRUE)`,
+	)
+
+	builder := Select[bunny]().
+		Where(In("Name", Select[carrot]().WithFields("BunnyName").Where(IsTrue("Crunchy")))).
+		Or(NotIn("Name", "ollie", "oliver"))
+
+	query, args, err := builder.BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`SELECT "Name", "EarLength" FROM "bunny" `+
+			`WHERE "Name" IN (SELECT "BunnyName" FROM "carrot" WHERE "Crunchy" = ?) OR "Name" NOT IN (?, ?);`,
+		query,
+	)
+	assert.Equal(t, []any{true, "ollie", "oliver"}, args)
+
+	bunnies, err := builder.Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"oliver", 20}}, bunnies)
+}
+
+func TestSelectNoWhere(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	base := Select[bunny]().
+		Where(Equal("Name", "ollie")).
+		Limit(10)
+
+	query, args, err := base.
+		NoWhere().
+		Where(GreaterThan("EarLength", 20)).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Name", "EarLength" FROM "bunny" WHERE "EarLength" > ? LIMIT ?;`, query)
+	assert.Equal(t, []any{20, uint64(10)}, args)
+
+	// The base builder is unaffected.
+	query, args, err = base.BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Name", "EarLength" FROM "bunny" WHERE "Name" = ? LIMIT ?;`, query)
+	assert.Equal(t, []any{"ollie", uint64(10)}, args)
+}
+
+func TestSelectWithRawConditions(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	db := SetupMemoryTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "EarLength" FLOAT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 15)`,
+		`INSERT INTO "bunny" VALUES('oliver', 20)`,
+		`INSERT INTO "bunny" VALUES('king ollie', 30)`,
+	)
+
+	builder := Select[bunny]().
+		Where(GreaterThan("EarLength", 10)).
+		AndRaw(`LENGTH("Name") < ? OR "Name" = ?`, 6, "king ollie").
+		And(NotEqual("Name", "ollie")).
+		OrRaw(`"EarLength" = 15`)
+
+	query, args, err := builder.BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`SELECT "Name", "EarLength" FROM "bunny" `+
+			`WHERE "EarLength" > ? AND (LENGTH("Name") < ? OR "Name" = ?) AND "Name" <> ? OR ("EarLength" = 15);`,
+		query,
+	)
+	assert.Equal(t, []any{10, 6, "king ollie", "ollie"}, args)
+
+	bunnies, err := builder.Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", 15}, {"king ollie", 30}}, bunnies)
+}
+
+func TestSelectWithEmptyRawCondition(t *testing.T) {
+	type bunny struct {
+		Name string
+	}
+
+	_, _, err := Select[bunny]().
+		Where(Equal("Name", "ollie")).
+		OrRaw(" ").
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrEmptyRawCondition, err)
+}
+
+func TestSelectQualifyColumns(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64 `db:"ear_length"`
+	}
+
+	query, _, err := Select[bunny]().
+		From("burrow.bunnies").
+		QualifyColumns().
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "burrow"."bunnies"."Name", "burrow"."bunnies"."ear_length" FROM "burrow"."bunnies";`, query)
+
+	query, _, err = Select[bunny]().
+		WithFields("Name").
+		QualifyColumns().
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "bunny"."Name" FROM "bunny";`, query)
+}
+
+func TestSelectQualifyColumnsAndQuery(t *testing.T) {
+	type bunny struct {
+		Name       string
+		CarrotName string
+	}
+
+	db := SetupMemoryTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("BunnyID" INT, "Name" TEXT, "CarrotName" TEXT);`,
+		`CREATE TABLE "carrot" ("BunnyID" INT, "CarrotName" TEXT);`,
+		`INSERT INTO "bunny" VALUES(1, 'ollie', 'favourite')`,
+		`INSERT INTO "carrot" VALUES(1, 'orange crunch')`,
+	)
+
+	bunnies, err := Select[bunny]().
+		JoinUsing("carrot", "BunnyID").
+		QualifyColumns().
+		Query(db)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", "favourite"}}, bunnies)
+}
+
+func TestSelectInTupleAndQuery(t *testing.T) {
+	type carrot struct {
+		Farm string
+		Row  int64
+		Name string
+	}
+
+	db := SetupMemoryTestDatabase(
+		t,
+		`CREATE TABLE "carrot" ("Farm" TEXT, "Row" INT, "Name" TEXT);`,
+		`INSERT INTO "carrot" VALUES('ollie''s', 1, 'crunchy')`,
+		`INSERT INTO "carrot" VALUES('ollie''s', 2, 'crunchier')`,
+		`INSERT INTO "carrot" VALUES('oliver''s', 1, 'crunchiest')`,
+	)
+
+	carrots, err := Select[carrot]().
+		Where(InTuple([]string{"Farm", "Row"}, []any{"ollie's", 2}, []any{"oliver's", 1})).
+		Query(db)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []carrot{{"ollie's", 2, "crunchier"}, {"oliver's", 1, "crunchiest"}}, carrots)
+}
+
+func TestSelectWithComment(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	db := SetupMemoryTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "EarLength" FLOAT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 15)`,
+	)
+
+	builder := Select[bunny]().
+		WithComment("route=GetBunny */").
+		Where(Equal("Name", "ollie"))
+
+	query, _, err := builder.BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `/* route=GetBunny */ SELECT "Name", "EarLength" FROM "bunny" WHERE "Name" = ?;`, query)
+
+	bunnies, err := builder.Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", 15}}, bunnies)
+}
+
+func TestSelectPretty(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	db := SetupMemoryTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "EarLength" FLOAT);`,
+		`CREATE TABLE "hutch" ("Name" TEXT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 15)`,
+		`INSERT INTO "hutch" VALUES('ollie')`,
+	)
+
+	builder := Select[bunny]().
+		JoinUsing("hutch", "Name").
+		Where(Equal("Name", "ollie")).
+		Limit(1).
+		Offset(0)
+
+	compactQuery, compactArgs, err := builder.BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`SELECT "Name", "EarLength" FROM "bunny" JOIN "hutch" USING ("Name") WHERE "Name" = ? LIMIT ? OFFSET ?;`,
+		compactQuery,
+	)
+
+	query, args, err := builder.Pretty().BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`SELECT "Name", "EarLength"
+FROM "bunny"
+JOIN "hutch" USING ("Name")
+WHERE "Name" = ?
+LIMIT ? OFFSET ?;`,
+		query,
+	)
+	assert.Equal(t, compactArgs, args)
+
+	bunnies, err := builder.Pretty().Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", 15}}, bunnies)
+}
+
+func TestSelectReadHint(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	db := SetupMemoryTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "EarLength" FLOAT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 15)`,
+	)
+
+	builder := Select[bunny]().
+		WithComment("route=GetBunny").
+		ReadHint("MAX_EXECUTION_TIME(1000)").
+		Where(Equal("Name", "ollie"))
+
+	query, _, err := builder.BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`/* route=GetBunny */ SELECT /*+ MAX_EXECUTION_TIME(1000) */ "Name", "EarLength" FROM "bunny" WHERE "Name" = ?;`,
+		query,
+	)
+
+	// The hint is only a comment to databases which do not understand it.
+	bunnies, err := builder.Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", 15}}, bunnies)
+}
+
+func TestSelectForUpdateSkipLocked(t *testing.T) {
+	type job struct {
+		ID      int64
+		Claimed bool
+	}
+
+	query, args, err := Select[job]().
+		Where(IsFalse("Claimed")).
+		Limit(1).
+		ForUpdateSkipLocked().
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "ID", "Claimed" FROM "job" WHERE "Claimed" = ? LIMIT ? FOR UPDATE SKIP LOCKED;`, query)
+	assert.Equal(t, []any{false, uint64(1)}, args)
+}
+
+func TestSelectAndQueryWithTransaction(t *testing.T) {
+	type job struct {
+		ID      int64
+		Claimed bool
+	}
+
+	db := SetupMemoryTestDatabase(
+		t,
+		`CREATE TABLE "job" ("ID" INT, "Claimed" BOOLEAN);`,
+		`INSERT INTO "job" VALUES(1, TRUE)`,
+		`INSERT INTO "job" VALUES(2, FALSE)`,
+	)
+
+	tx, err := db.Begin()
+	assert.NoError(t, err)
+
+	next, err := Select[job]().
+		Where(IsFalse("Claimed")).
+		GetOne(tx)
+	assert.NoError(t, err)
+	assert.Equal(t, &job{2, false}, next)
+
+	_, err = Update[job]().
+		SetStruct(job{2, true}).
+		Where(Equal("ID", next.ID)).
+		Exec(tx)
+	assert.NoError(t, err)
+
+	assert.NoError(t, tx.Commit())
+
+	_, err = Select[job]().
+		Where(IsFalse("Claimed")).
+		GetOne(db)
+	assert.ErrorIs(t, err, ErrNoRows)
+}
+
+func TestSelectMatchColumnsAndQuery(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64 `db:"ear_length"`
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "ear_length" FLOAT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 15)`,
+	)
+
+	// The db tag is used to match too, so even loose matching finds the column.
+	bunnies, err := Select[bunny]().
+		MatchColumns(ColumnMatchLoose).
+		Query(db)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", 15}}, bunnies)
+}
+
+func TestSelectWithDuplicateColumn(t *testing.T) {
+	type bunny struct {
+		Name     string
+		Nickname string `db:"Name"`
+	}
+
+	_, _, err := Select[bunny]().BuildQuery()
+
+	assert.ErrorIs(t, err, ErrDuplicateColumn{"Name", [2]string{"Name", "Nickname"}})
+}
+
+func TestSelectColumnRaw(t *testing.T) {
+	type bunny struct {
+		Name string
+		Rank int64
+	}
+
+	query, args, err := Select[bunny]().
+		ColumnRaw(`COUNT(*)`, "Total").
+		ColumnRaw(`ROW_NUMBER() OVER (PARTITION BY "Farm" ORDER BY "Age")`, "Rank").
+		QualifyColumns().
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`SELECT "bunny"."Name", ROW_NUMBER() OVER (PARTITION BY "Farm" ORDER BY "Age") AS "Rank", COUNT(*) AS "Total" `+
+			`FROM "bunny";`,
+		query,
+	)
+	assert.Empty(t, args)
+}
+
+func TestSelectColumnRawAndQuery(t *testing.T) {
+	type bunny struct {
+		Name string
+		Rank int64
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "Farm" TEXT, "Age" INT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 'ollie''s farm', 2)`,
+		`INSERT INTO "bunny" VALUES('oliver', 'ollie''s farm', 1)`,
+		`INSERT INTO "bunny" VALUES('king ollie', 'the castle', 9)`,
+	)
+
+	bunnies, err := Select[bunny]().
+		ColumnRaw(`ROW_NUMBER() OVER (PARTITION BY "Farm" ORDER BY "Age")`, "Rank").
+		Where(Equal("Farm", "ollie's farm")).
+		Query(db)
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []bunny{{"oliver", 1}, {"ollie", 2}}, bunnies)
+}
+
+func TestSelectColumnRawInvalid(t *testing.T) {
+	type bunny struct {
+		Name string
+	}
+
+	_, _, err := Select[bunny]().ColumnRaw(" ", "Rank").BuildQuery()
+	assert.ErrorIs(t, err, ErrEmptyRawColumn)
+
+	_, _, err = Select[bunny]().ColumnRaw(`COUNT(*)`, `Rank"`).BuildQuery()
+	assert.ErrorIs(t, err, ErrInvalidColumnName{`Rank"`})
+}
+
+func TestSelectGetOneLimit(t *testing.T) {
+	type bunny struct {
+		Name string
+	}
+
+	db := NewRecordingExecutor()
+	defer db.Close()
+
+	_, err := Select[bunny]().GetOne(db)
+	assert.ErrorIs(t, err, ErrNoRows)
+
+	_, err = Select[bunny]().Limit(5).GetOne(db)
+	assert.ErrorIs(t, err, ErrNoRows)
+
+	assert.Equal(
+		t,
+		[]RecordedQuery{
+			{`SELECT "Name" FROM "bunny" LIMIT ?;`, []any{uint64(1)}},
+			{`SELECT "Name" FROM "bunny" LIMIT ?;`, []any{uint64(5)}},
+		},
+		db.Queries(),
+	)
+}
+
+func TestSelectWithFieldsIntoLargerStruct(t *testing.T) {
+	type bunny struct {
+		Name         string
+		Age          int64
+		EarLength    float64
+		FavoriteFood string
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "Age" INT, "EarLength" FLOAT, "FavoriteFood" TEXT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 2, 15, 'carrot')`,
+	)
+
+	// Only the selected columns are populated, and the rest are left as the zero value.
+	bunnies, err := Select[bunny]().
+		WithFields("FavoriteFood", "Name").
+		Query(db)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{Name: "ollie", FavoriteFood: "carrot"}}, bunnies)
+}
+
+func TestSelectCaseColumn(t *testing.T) {
+	type bunny struct {
+		Name  string
+		Group string
+	}
+
+	query, args, err := Select[bunny]().
+		CaseColumn(CaseWhen(LessThan("Age", 1), "kit").Else("adult"), "Group").
+		Where(Equal("Farm", "ollie's farm")).
+		Limit(10).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`SELECT "Name", CASE WHEN "Age" < ? THEN ? ELSE ? END AS "Group" FROM "bunny" WHERE "Farm" = ? LIMIT ?;`,
+		query,
+	)
+	assert.Equal(t, []any{1, "kit", "adult", "ollie's farm", uint64(10)}, args)
+}
+
+func TestSelectCaseColumnAndQuery(t *testing.T) {
+	type bunny struct {
+		Name  string
+		Group string
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "Age" INT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 2)`,
+		`INSERT INTO "bunny" VALUES('oliver', 0)`,
+	)
+
+	bunnies, err := Select[bunny]().
+		CaseColumn(CaseWhen(LessThan("Age", 1), "kit").Else("adult"), "Group").
+		Query(db)
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []bunny{{"ollie", "adult"}, {"oliver", "kit"}}, bunnies)
+}
+
+func TestSelectCountDistinct(t *testing.T) {
+	type carrot struct {
+		BunnyName string
+		Crunchy   bool
+	}
+
+	db := SetupTestDatabase(t, `CREATE TABLE "carrot" ("BunnyName" TEXT, "Crunchy" BOOLEAN);`)
+
+	count, err := Select[carrot]().CountDistinct("BunnyName", db)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+
+	_, err = Insert[carrot]().
+		Values(carrot{"ollie", true}, carrot{"ollie", true}, carrot{"oliver", true}, carrot{"king ollie", false}).
+		Exec(db)
+	assert.NoError(t, err)
+
+	count, err = Select[carrot]().
+		Where(IsTrue("Crunchy")).
+		CountDistinct("BunnyName", db)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	_, err = Select[carrot]().CountDistinct(`Bunny"Name`, db)
+	assert.ErrorIs(t, err, ErrInvalidColumnName{`Bunny"Name`})
+}
+
+func TestSelectAs(t *testing.T) {
+	type users struct {
+		ID   int64
+		Name string
+	}
+
+	query, args, err := Select[users]().
+		As("u").
+		QualifyColumns().
+		Where(Equal("ID", 42)).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "u"."ID", "u"."Name" FROM "users" AS "u" WHERE "ID" = ?;`, query)
+	assert.Equal(t, []any{42}, args)
+
+	_, _, err = Select[users]().As(`u"`).BuildQuery()
+	assert.ErrorIs(t, err, ErrInvalidTableName{`u"`})
+}
+
+func TestSelectAsAndQuery(t *testing.T) {
+	type bunny struct {
+		Name string
+		Age  int64
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "Age" INT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 2)`,
+		`INSERT INTO "bunny" VALUES('king ollie', 300)`,
+	)
+
+	bunnies, err := Select[bunny]().
+		As("b").
+		QualifyColumns().
+		Where(GreaterThan("Age", 100)).
+		Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"king ollie", 300}}, bunnies)
+}
+
+func TestSelectActiveAt(t *testing.T) {
+	type carrotSale struct {
+		Name     string
+		StartsAt time.Time
+		EndsAt   time.Time
+	}
+
+	day := func(d int) time.Time { return time.Date(2024, time.April, d, 0, 0, 0, 0, time.UTC) }
+
+	db := SetupTestDatabase(t, `CREATE TABLE "carrotSale" ("Name" TEXT, "StartsAt" DATETIME, "EndsAt" DATETIME);`)
+
+	_, err := Insert[carrotSale]().
+		Values(carrotSale{"spring", day(1), day(10)}, carrotSale{"easter", day(10), day(12)}).
+		Exec(db)
+	assert.NoError(t, err)
+
+	sales, err := Select[carrotSale]().
+		WhereGroup(ActiveAt("StartsAt", "EndsAt", day(10))).
+		Query(db)
+	assert.NoError(t, err)
+	assert.Len(t, sales, 2)
+
+	query, args, err := Select[carrotSale]().
+		Where(Equal("Name", "easter")).
+		AndGroup(ActiveAt("StartsAt", "EndsAt", day(11))).
+		BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`SELECT "Name", "StartsAt", "EndsAt" FROM "carrotSale" WHERE "Name" = ? AND ("StartsAt" <= ? AND "EndsAt" >= ?);`,
+		query,
+	)
+	assert.Equal(t, []any{"easter", day(11), day(11)}, args)
+}
+
+func TestSelectStrictColumnsAndQuery(t *testing.T) {
+	type bunny struct {
+		Name string
+		Age  int64
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "Age" INT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 2)`,
+	)
+
+	bunnies, err := Select[bunny]().StrictColumns().Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", 2}}, bunnies)
+
+	// Lenient by default, the missing field is left as the zero value.
+	bunnies, err = Select[bunny]().WithFields("Name").Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{Name: "ollie"}}, bunnies)
+
+	// A field left out by WithFields is not expected to have a column.
+	bunnies, err = Select[bunny]().WithFields("Name").StrictColumns().Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{Name: "ollie"}}, bunnies)
+
+	_, err = Select[bunny]().ColumnRaw(`"Age" * 12`, "AgeMonths").StrictColumns().Query(db)
+	assert.ErrorIs(t, err, ErrColumnMismatch{Column: "AgeMonths"})
+}
+
+func TestSelectTimeout(t *testing.T) {
+	type bunny struct {
+		Name string
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT);`,
+		`INSERT INTO "bunny" VALUES('ollie')`,
+	)
+
+	// Counting carrots forever, so this only finishes when interrupted.
+	forever := `(WITH RECURSIVE "carrots"("n") AS (SELECT 1 UNION ALL SELECT "n" + 1 FROM "carrots") ` +
+		`SELECT MAX("n") FROM "carrots") > 0`
+
+	start := time.Now()
+	_, err := Select[bunny]().
+		Where(Equal("Name", "ollie")).
+		AndRaw(forever).
+		Timeout(50 * time.Millisecond).
+		Query(db)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestSelectIsNotDistinctFromAndQuery(t *testing.T) {
+	type bunny struct {
+		Name     string
+		Nickname *string
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "Nickname" TEXT);`,
+		`INSERT INTO "bunny" VALUES('ollie', NULL)`,
+		`INSERT INTO "bunny" VALUES('oliver', 'ols')`,
+	)
+
+	query, args, err := Select[bunny]().Where(IsNotDistinctFrom("Nickname", nil)).BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Name", "Nickname" FROM "bunny" WHERE "Nickname" IS NOT DISTINCT FROM ?;`, query)
+	assert.Equal(t, []any{nil}, args)
+
+	// Equal would match nothing here, since NULL = NULL is not true.
+	bunnies, err := Select[bunny]().WithFields("Name").Where(IsNotDistinctFrom("Nickname", nil)).Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{Name: "ollie"}}, bunnies)
+
+	bunnies, err = Select[bunny]().WithFields("Name").Where(Equal("Nickname", nil)).Query(db)
+	assert.NoError(t, err)
+	assert.Empty(t, bunnies)
+}
+
+func TestSelectNullSafeEqualAndQuery(t *testing.T) {
+	type bunny struct {
+		Name     string
+		Nickname *string
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "Nickname" TEXT);`,
+		`INSERT INTO "bunny" VALUES('ollie', NULL)`,
+		`INSERT INTO "bunny" VALUES('oliver', 'ols')`,
+	)
+
+	assert.Equal(t, IsNotDistinctFrom("Nickname", nil), NullSafeEqual("Nickname", nil))
+
+	query, args, err := Select[bunny]().Where(NullSafeEqual("Nickname", nil)).BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Name", "Nickname" FROM "bunny" WHERE "Nickname" IS NOT DISTINCT FROM ?;`, query)
+	assert.Equal(t, []any{nil}, args)
+
+	bunnies, err := Select[bunny]().WithFields("Name").Where(NullSafeEqual("Nickname", nil)).Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{Name: "ollie"}}, bunnies)
+}
+
+func TestSelectLazyFieldsAndQuery(t *testing.T) {
+	type bunny struct {
+		Name  string
+		Photo []byte `db:"Photo,lazy"`
+	}
+
+	db := SetupTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "Photo" BLOB);`,
+		`INSERT INTO "bunny" VALUES('ollie', x'cafe')`,
+	)
+
+	query, _, err := Select[bunny]().BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Name" FROM "bunny";`, query)
+
+	bunnies, err := Select[bunny]().StrictColumns().Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{Name: "ollie"}}, bunnies)
+
+	bunnies, err = Select[bunny]().WithFields("Name", "Photo").Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", []byte{0xca, 0xfe}}}, bunnies)
+}
+
+func TestSelectFetchFirst(t *testing.T) {
+	type bunny struct {
+		Name string
+	}
+
+	query, args, err := Select[bunny]().
+		Where(Equal("Name", "ollie")).
+		Limit(10).
+		Offset(20).
+		FetchFirst().
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Name" FROM "bunny" WHERE "Name" = ? OFFSET ? ROWS FETCH FIRST ? ROWS ONLY;`, query)
+	assert.Equal(t, []any{"ollie", uint64(20), uint64(10)}, args)
+
+	query, args, err = Select[bunny]().Limit(10).FetchFirst().BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Name" FROM "bunny" FETCH FIRST ? ROWS ONLY;`, query)
+	assert.Equal(t, []any{uint64(10)}, args)
+}
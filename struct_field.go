@@ -1,10 +1,160 @@
 package qubr
 
-import "reflect"
+import (
+	"reflect"
+	"strings"
+)
+
+// checkStructType will return an ErrNotAStruct if the type is not a struct, since all fields are derived from one.
+func checkStructType(t reflect.Type) error {
+	if t.Kind() != reflect.Struct {
+		return ErrNotAStruct{t}
+	}
+	return nil
+}
+
+// View is implemented by types which map to a database view, rather than a table.
+// When IsView returns true, the InsertBuilder, UpdateBuilder, and DeleteBuilder for the type will return an
+// ErrReadOnlyType, instead of a query the database would reject. The SelectBuilder is unaffected.
+type View interface {
+	IsView() bool
+}
+
+// checkWritable will return an ErrReadOnlyType if T is a View, so cannot be written to.
+func checkWritable[T any]() error {
+	// A pointer has the methods of both value and pointer receivers.
+	if v, ok := any(new(T)).(View); ok && v.IsView() {
+		return ErrReadOnlyType{reflect.TypeFor[T]()}
+	}
+	return nil
+}
+
+// Validator is implemented by types which can check their own values, before they are written to the database.
+// When T is a Validator, the InsertBuilder calls Validate for each row, and the UpdateBuilder calls it for the struct
+// set using UpdateBuilder.SetStruct or UpdateBuilder.SetDiff. The first error is returned by BuildQuery, as it is.
+type Validator interface {
+	Validate() error
+}
+
+// validate will call Validate on v, if T is a Validator. Otherwise, this does nothing.
+func validate[T any](v T) error {
+	// Like checkWritable, a pointer has the methods of both value and pointer receivers.
+	if validator, ok := any(&v).(Validator); ok {
+		return validator.Validate()
+	}
+	return nil
+}
+
+// checkExportedFields will return an ErrUnexportedField for the first unexported field found on the struct type.
+func checkExportedFields(t reflect.Type) error {
+	for i := range t.NumField() {
+		if f := t.Field(i); !f.IsExported() {
+			return ErrUnexportedField{f.Name}
+		}
+	}
+	return nil
+}
+
+// checkDuplicateColumns will return an ErrDuplicateColumn for the first column name which more than one exported field
+// of the struct type maps to.
+func checkDuplicateColumns(t reflect.Type) error {
+	fieldsByColumn := make(map[string]string)
+	for _, f := range exportedFields(t) {
+		name := structFieldName(f)
+		if other, ok := fieldsByColumn[name]; ok {
+			return ErrDuplicateColumn{name, [2]string{other, f.Name}}
+		}
+		fieldsByColumn[name] = f.Name
+	}
+	return nil
+}
+
+// exportedFields are the fields of the struct type which are mapped to columns, in the order they are declared.
+func exportedFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for i := range t.NumField() {
+		if f := t.Field(i); f.IsExported() {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// ArgsOf will collect the values of the exported fields of v, in the order they are declared, to be used as the args of
+// a hand-written query. Like an InsertBuilder, any transform in the "db" tag of a field is applied to its value.
+// Example:
+//
+//	args, err := ArgsOf(bunny)
+//	if err != nil {
+//		return err
+//	}
+//	_, err = db.ExecContext(ctx, `INSERT INTO "bunny" ("Name", "EarLength") VALUES (?, ?);`, args...)
+func ArgsOf[T any](v T) ([]any, error) {
+	t := reflect.TypeFor[T]()
+	if err := checkStructType(t); err != nil {
+		return nil, err
+	}
+
+	value := reflect.ValueOf(v)
+
+	var args []any
+	for _, f := range exportedFields(t) {
+		arg, err := encodeFieldValue(f, value.FieldByIndex(f.Index).Interface(), TimeFormatNative)
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, arg)
+	}
+
+	return args, nil
+}
+
+// fieldColumnName is the column for a name given by the caller, which is either a column, or the name of a field of T.
+// Names are only converted while snake_case columns are enabled, see SetSnakeCaseColumns.
+func fieldColumnName[T any](name string) string {
+	if !snakeCaseColumns.Load() {
+		return name
+	}
+	if f, ok := reflect.TypeFor[T]().FieldByName(name); ok && f.IsExported() {
+		return structFieldName(f)
+	}
+	return name
+}
 
 func structFieldName(field reflect.StructField) string {
-	if dbName, ok := field.Tag.Lookup("db"); ok {
-		return dbName
+	if name, _ := parseStructFieldTag(field); name != "" {
+		return name
+	}
+	if snakeCaseColumns.Load() {
+		return toSnakeCase(field.Name)
 	}
 	return field.Name
 }
+
+// structFieldOption will look up an option of the "db" tag. Options come after the name, and are comma separated.
+// An option is either a flag, like `db:"name,flag"`, or has a value, like `db:"name,key=value"`.
+func structFieldOption(field reflect.StructField, key string) (value string, ok bool) {
+	_, options := parseStructFieldTag(field)
+	for _, option := range options {
+		k, v, _ := strings.Cut(option, "=")
+		if k == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func parseStructFieldTag(field reflect.StructField) (name string, options []string) {
+	tag, ok := field.Tag.Lookup("db")
+	if !ok {
+		return "", nil
+	}
+
+	name, rest, hasOptions := strings.Cut(tag, ",")
+	if hasOptions {
+		options = strings.Split(rest, ",")
+	}
+
+	return name, options
+}
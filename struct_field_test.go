@@ -0,0 +1,139 @@
+package qubr
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func Test_structFieldName(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64 `db:"ear_length"`
+		Secret    string  `db:"secret,transform=aes"`
+		Optioned  string  `db:",transform=aes"`
+	}
+
+	tests := []struct {
+		name  string
+		field string
+		want  string
+	}{
+		{name: "no tag", field: "Name", want: "Name"},
+		{name: "tag name only", field: "EarLength", want: "ear_length"},
+		{name: "tag name and options", field: "Secret", want: "secret"},
+		{name: "tag options only", field: "Optioned", want: "Optioned"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, _ := reflect.TypeFor[bunny]().FieldByName(tt.field)
+			assert.Equal(t, tt.want, structFieldName(f))
+		})
+	}
+}
+
+func Test_structFieldOption(t *testing.T) {
+	type bunny struct {
+		Secret string `db:"secret,lazy,transform=aes"`
+	}
+	f, _ := reflect.TypeFor[bunny]().FieldByName("Secret")
+
+	v, ok := structFieldOption(f, "transform")
+	assert.True(t, ok)
+	assert.Equal(t, "aes", v)
+
+	v, ok = structFieldOption(f, "lazy")
+	assert.True(t, ok)
+	assert.Equal(t, "", v)
+
+	_, ok = structFieldOption(f, "default")
+	assert.False(t, ok)
+}
+
+type bunnySummary struct {
+	Name      string
+	EarLength float64
+}
+
+func (bunnySummary) IsView() bool {
+	return true
+}
+
+func TestReadOnlyView(t *testing.T) {
+	viewType := reflect.TypeFor[bunnySummary]()
+
+	_, _, err := Insert[bunnySummary]().Values(bunnySummary{"ollie", 15}).BuildQuery()
+	assert.ErrorIs(t, ErrReadOnlyType{viewType}, err)
+
+	_, _, err = Update[bunnySummary]().SetStruct(bunnySummary{"ollie", 15}).BuildQuery()
+	assert.ErrorIs(t, ErrReadOnlyType{viewType}, err)
+
+	_, _, err = Delete[bunnySummary]().BuildQuery()
+	assert.ErrorIs(t, ErrReadOnlyType{viewType}, err)
+
+	query, _, err := Select[bunnySummary]().BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `SELECT "Name", "EarLength" FROM "bunnySummary";`, query)
+}
+
+var errNegativeEarLength = errors.New("ear length cannot be negative")
+
+type validatedBunny struct {
+	Name      string
+	EarLength float64
+}
+
+func (b validatedBunny) Validate() error {
+	if b.EarLength < 0 {
+		return errNegativeEarLength
+	}
+	return nil
+}
+
+func TestValidator(t *testing.T) {
+	_, _, err := Insert[validatedBunny]().
+		Values(validatedBunny{"ollie", 15}, validatedBunny{"oliver", -1}).
+		BuildQuery()
+	assert.ErrorIs(t, err, errNegativeEarLength)
+
+	_, _, err = Update[validatedBunny]().SetStruct(validatedBunny{"oliver", -1}).BuildQuery()
+	assert.ErrorIs(t, err, errNegativeEarLength)
+
+	_, _, err = Update[validatedBunny]().
+		SetDiff(validatedBunny{"oliver", 15}, validatedBunny{"oliver", -1}).
+		BuildQuery()
+	assert.ErrorIs(t, err, errNegativeEarLength)
+
+	query, args, err := Insert[validatedBunny]().Values(validatedBunny{"ollie", 15}).BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `INSERT INTO "validatedBunny" VALUES (?, ?);`, query)
+	assert.Equal(t, []any{"ollie", 15.0}, args)
+
+	// Individually set values are not validated, since there is no struct to validate.
+	_, _, err = Update[validatedBunny]().Set("EarLength", -1).BuildQuery()
+	assert.NoError(t, err)
+}
+
+func TestArgsOf(t *testing.T) {
+	RegisterTransform(
+		"test-shout",
+		func(v any) (any, error) { return strings.ToUpper(v.(string)), nil },
+		func(v any) (any, error) { return strings.ToLower(v.(string)), nil },
+	)
+
+	type bunny struct {
+		Name      string
+		secret    string
+		EarLength float64 `db:"ear_length"`
+		Farm      string  `db:"farm,transform=test-shout"`
+	}
+
+	args, err := ArgsOf(bunny{"ollie", "carrots", 15, "ollie's farm"})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"ollie", 15.0, "OLLIE'S FARM"}, args)
+
+	_, err = ArgsOf("ollie")
+	assert.ErrorIs(t, err, ErrNotAStruct{reflect.TypeFor[string]()})
+}
@@ -0,0 +1,61 @@
+package qubr
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Subquery is a QueryBuilder which is used as the value of a FieldOperation.
+// The query is rendered inline within parentheses, and its args are merged in where the Subquery is used.
+type Subquery struct {
+	builder QueryBuilder
+	scalar  bool
+}
+
+// ScalarSubquery will construct a Subquery which results in a single value, for use in comparisons.
+// The builder must only select a single field.
+// Example:
+//
+//	Select[Product]().
+//		Where(GreaterThan("Price", ScalarSubquery(Select[Product]().WithFields("Price").Limit(1))))
+//
+// Equivalent SQL will be:
+//
+//	"Price" > (SELECT "Price" FROM "Product" LIMIT ?)
+func ScalarSubquery(builder QueryBuilder) Subquery {
+	return Subquery{builder: builder, scalar: true}
+}
+
+// fieldCounter is implemented by QueryBuilders which know the number of fields they will select.
+type fieldCounter interface {
+	numFields() int
+}
+
+// rawHolder is implemented by QueryBuilders which know whether they place raw SQL into their query.
+type rawHolder interface {
+	hasRaw() bool
+}
+
+// hasRaw will report whether the builder of the Subquery places raw SQL into its query, so that safe mode applies to
+// the Subquery wherever it is used.
+func (s Subquery) hasRaw() bool {
+	holder, ok := s.builder.(rawHolder)
+	return ok && holder.hasRaw()
+}
+
+func (s Subquery) queryData() (string, []any, error) {
+	if s.builder == nil {
+		return "", nil, ErrNilSubquery
+	}
+
+	query, args, err := s.builder.BuildQuery()
+	if err != nil {
+		return "", nil, err
+	}
+
+	if counter, ok := s.builder.(fieldCounter); ok && s.scalar && counter.numFields() != 1 {
+		return "", nil, ErrScalarSubqueryFields
+	}
+
+	return fmt.Sprintf("(%s)", strings.TrimSuffix(query, ";")), args, nil
+}
@@ -0,0 +1,50 @@
+package qubr
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSubquery_queryData(t *testing.T) {
+	type carrot struct {
+		Crunchiness float64
+		Farm        string
+	}
+
+	tests := []struct {
+		name      string
+		subquery  Subquery
+		wantQuery string
+		wantArgs  []any
+		wantErr   error
+	}{
+		{
+			name:      "scalar with a single field",
+			subquery:  ScalarSubquery(Select[carrot]().WithFields("Crunchiness").Where(Equal("Farm", "ollie's")).Limit(1)),
+			wantQuery: `(SELECT "Crunchiness" FROM "carrot" WHERE "Farm" = ? LIMIT ?)`,
+			wantArgs:  []any{"ollie's", uint64(1)},
+		},
+		{
+			name:     "scalar with many fields",
+			subquery: ScalarSubquery(Select[carrot]()),
+			wantErr:  ErrScalarSubqueryFields,
+		},
+		{
+			name:     "builder error",
+			subquery: ScalarSubquery(Select[carrot]().Limit(1).Limit(1)),
+			wantErr:  ErrLimitAlreadySet,
+		},
+		{
+			name:    "no builder",
+			wantErr: ErrNilSubquery,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotQuery, gotArgs, err := tt.subquery.queryData()
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantQuery, gotQuery)
+			assert.Equal(t, tt.wantArgs, gotArgs)
+		})
+	}
+}
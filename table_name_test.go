@@ -104,3 +104,53 @@ func Test_tableName_String(t1 *testing.T) {
 		})
 	}
 }
+
+func TestReservedWordIdentifiers(t *testing.T) {
+	// Every field is a reserved word, but since identifiers are always quoted, these are valid column names.
+	type order struct {
+		Select string
+		Group  int64 `db:"group"`
+		Where  bool  `db:"where"`
+	}
+
+	tests := []struct {
+		name      string
+		tableName string
+	}{
+		{name: "from type", tableName: ""},
+		{name: "table", tableName: "table"},
+		{name: "schema and table", tableName: "main.select"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := tableName{forType: reflect.TypeFor[order]()}
+			insert, update, sel, del := Insert[order](), Update[order](), Select[order](), Delete[order]()
+			if tt.tableName != "" {
+				tn, err := newTableNameFromString(tt.tableName)
+				assert.NoError(t, err)
+				table = *tn
+
+				insert, update = insert.Into(tt.tableName), update.Into(tt.tableName)
+				sel, del = sel.From(tt.tableName), del.From(tt.tableName)
+			}
+
+			db := SetupMemoryTestDatabase(
+				t,
+				fmt.Sprintf(`CREATE TABLE %s ("Select" TEXT, "group" INT, "where" BOOLEAN);`, table),
+			)
+
+			_, err := insert.Values(order{"ollie", 1, true}, order{"oliver", 2, false}).Exec(db)
+			assert.NoError(t, err)
+
+			_, err = update.Set("group", 3).Where(Equal("Select", "oliver")).Exec(db)
+			assert.NoError(t, err)
+
+			_, err = del.Where(IsTrue("where")).Exec(db)
+			assert.NoError(t, err)
+
+			orders, err := sel.Where(GreaterThan("group", 0)).QualifyColumns().Query(db)
+			assert.NoError(t, err)
+			assert.Equal(t, []order{{"oliver", 3, false}}, orders)
+		})
+	}
+}
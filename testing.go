@@ -2,12 +2,16 @@ package qubr
 
 import (
 	"database/sql"
+	"fmt"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
+	"net/url"
 	"os"
 	"testing"
 )
 
+// SetupTestDatabase will create a sqlite database, backed by a temp file, and run the setup queries against it.
+// This is exported so that tests outside of qubr can make use of the same harness.
 func SetupTestDatabase(t *testing.T, setupQueries ...string) *sql.DB {
 	// Create a temp file so that the sqlite file is not populating random directories.
 	f, err := os.CreateTemp("", "qubr-test-data")
@@ -15,11 +19,37 @@ func SetupTestDatabase(t *testing.T, setupQueries ...string) *sql.DB {
 	db, err := sql.Open("sqlite3", f.Name())
 	assert.NoError(t, err, "could not connect to sqlite3")
 
+	runSetupQueries(t, db, setupQueries)
+
+	return db
+}
+
+// SetupMemoryTestDatabase is like SetupTestDatabase, but the sqlite database is only kept in memory, which is faster,
+// and leaves no files behind. The database is shared between the connections of the returned sql.DB, and it is
+// closed, along with its data, once the test completes.
+// Use SetupTestDatabase instead, if the data needs to persist.
+func SetupMemoryTestDatabase(t *testing.T, setupQueries ...string) *sql.DB {
+	// The name keeps each test's database separate, while the shared cache keeps it the same across connections.
+	// It is escaped, since the names of subtests may contain characters such as "?" or "#", which would change the URI.
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared", url.PathEscape(t.Name())))
+	assert.NoError(t, err, "could not connect to sqlite3")
+
+	// An in-memory database is gone once all of its connections are closed, so we hold one open until cleanup.
+	db.SetConnMaxIdleTime(0)
+	db.SetMaxIdleConns(1)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	runSetupQueries(t, db, setupQueries)
+
+	return db
+}
+
+func runSetupQueries(t *testing.T, db *sql.DB, setupQueries []string) {
 	// Run the provided queries as a setup step.
 	for _, query := range setupQueries {
-		_, err = db.Exec(query)
+		_, err := db.Exec(query)
 		assert.NoError(t, err, "failed to run setup queries")
 	}
-
-	return db
 }
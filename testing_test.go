@@ -0,0 +1,56 @@
+package qubr
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSetupMemoryTestDatabase(t *testing.T) {
+	db := SetupMemoryTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT);`,
+		`INSERT INTO "bunny" VALUES('ollie')`,
+	)
+
+	// Each connection should see the same database, so we hold one while querying on another.
+	held, err := db.Conn(context.Background())
+	assert.NoError(t, err)
+	defer held.Close()
+
+	other, err := db.Conn(context.Background())
+	assert.NoError(t, err)
+	defer other.Close()
+
+	var count int
+	assert.NoError(t, other.QueryRowContext(context.Background(), `SELECT COUNT(*) FROM "bunny";`).Scan(&count))
+	assert.Equal(t, 1, count)
+
+	t.Run("is separate per test", func(t *testing.T) {
+		other := SetupMemoryTestDatabase(t)
+
+		_, err := other.Exec(`SELECT * FROM "bunny";`)
+		assert.Error(t, err)
+	})
+}
+
+func TestSetupMemoryTestDatabaseSubtestNames(t *testing.T) {
+	// Repeated subtest names are suffixed with "#01", and so on, which must not be read as part of the URI.
+	for _, name := range []string{"same name", "same name", "what? & why #1 / 100%"} {
+		t.Run(name, func(t *testing.T) {
+			db := SetupMemoryTestDatabase(
+				t,
+				`CREATE TABLE "bunny" ("Name" TEXT);`,
+				`INSERT INTO "bunny" VALUES('ollie')`,
+			)
+
+			var count int
+			assert.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM "bunny";`).Scan(&count))
+			assert.Equal(t, 1, count)
+
+			var file string
+			assert.NoError(t, db.QueryRow(`SELECT file FROM pragma_database_list WHERE name = 'main';`).Scan(&file))
+			assert.Empty(t, file, "database should not be backed by a file")
+		})
+	}
+}
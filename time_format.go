@@ -0,0 +1,60 @@
+package qubr
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeFormat is the representation used to store time.Time fields in the database.
+// This is particularly useful for SQLite, which has no native date and time type.
+type TimeFormat uint8
+
+const (
+	// TimeFormatNative passes time.Time values to the driver as they are, which is the default.
+	TimeFormatNative TimeFormat = iota
+	// TimeFormatUnix stores time.Time values as an integer of seconds since the unix epoch.
+	TimeFormatUnix
+	// TimeFormatUnixMilli stores time.Time values as an integer of milliseconds since the unix epoch.
+	TimeFormatUnixMilli
+	// TimeFormatRFC3339 stores time.Time values as RFC 3339 text, including any fractional seconds.
+	TimeFormatRFC3339
+)
+
+// encode will convert v to the storage representation, if it is a time.Time. Otherwise, v is returned as it is.
+func (f TimeFormat) encode(v any) any {
+	t, ok := v.(time.Time)
+	if !ok {
+		return v
+	}
+
+	switch f {
+	case TimeFormatUnix:
+		return t.Unix()
+	case TimeFormatUnixMilli:
+		return t.UnixMilli()
+	case TimeFormatRFC3339:
+		return t.Format(time.RFC3339Nano)
+	default:
+		return t
+	}
+}
+
+// decode will convert a scanned value from the storage representation back to a time.Time.
+func (f TimeFormat) decode(v any) (time.Time, error) {
+	switch v := v.(type) {
+	case time.Time:
+		// The driver has done this for us.
+		return v, nil
+	case int64:
+		if f == TimeFormatUnixMilli {
+			return time.UnixMilli(v), nil
+		}
+		return time.Unix(v, 0), nil
+	case string:
+		return time.Parse(time.RFC3339Nano, v)
+	case []byte:
+		return time.Parse(time.RFC3339Nano, string(v))
+	default:
+		return time.Time{}, fmt.Errorf("cannot decode %T as a time", v)
+	}
+}
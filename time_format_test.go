@@ -0,0 +1,132 @@
+package qubr
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTimeFormatRoundTrip(t *testing.T) {
+	type carrot struct {
+		Name     string
+		PickedAt time.Time
+	}
+
+	picked := time.Date(2024, time.March, 14, 9, 26, 53, 589_000_000, time.UTC)
+
+	tests := []struct {
+		name       string
+		format     TimeFormat
+		columnType string
+		wantStored any
+		want       time.Time
+	}{
+		{
+			name:       "unix",
+			format:     TimeFormatUnix,
+			columnType: "INT",
+			wantStored: picked.Unix(),
+			want:       picked.Truncate(time.Second),
+		},
+		{
+			name:       "unix milli",
+			format:     TimeFormatUnixMilli,
+			columnType: "INT",
+			wantStored: picked.UnixMilli(),
+			want:       picked,
+		},
+		{
+			name:       "rfc3339",
+			format:     TimeFormatRFC3339,
+			columnType: "TEXT",
+			wantStored: "2024-03-14T09:26:53.589Z",
+			want:       picked,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := SetupTestDatabase(t, `CREATE TABLE "carrot" ("Name" TEXT, "PickedAt" `+tt.columnType+`);`)
+
+			_, args, err := Insert[carrot]().
+				StoreTimeAs(tt.format).
+				Values(carrot{"crunchy", picked}).
+				BuildQuery()
+			assert.NoError(t, err)
+			assert.Equal(t, []any{"crunchy", tt.wantStored}, args)
+
+			_, err = Insert[carrot]().
+				StoreTimeAs(tt.format).
+				Values(carrot{"crunchy", picked}).
+				Exec(db)
+			assert.NoError(t, err)
+
+			got, err := Select[carrot]().
+				StoreTimeAs(tt.format).
+				GetOne(db)
+			assert.NoError(t, err)
+			assert.Equal(t, "crunchy", got.Name)
+			assert.True(t, tt.want.Equal(got.PickedAt), "expected %s, got %s", tt.want, got.PickedAt)
+		})
+	}
+}
+
+func TestTimeFormatWhere(t *testing.T) {
+	type carrot struct {
+		Name     string
+		PickedAt time.Time
+	}
+
+	picked := time.Date(2024, time.March, 14, 9, 26, 53, 0, time.UTC)
+	before, after := picked.Add(-time.Hour), picked.Add(time.Hour)
+
+	db := SetupMemoryTestDatabase(t, `CREATE TABLE "carrot" ("Name" TEXT, "PickedAt" INT);`)
+
+	_, err := Insert[carrot]().
+		StoreTimeAs(TimeFormatUnix).
+		Values(carrot{"crunchy", picked}, carrot{"soggy", after}).
+		Exec(db)
+	assert.NoError(t, err)
+
+	query, args, err := Update[carrot]().
+		StoreTimeAs(TimeFormatUnix).
+		Set("PickedAt", before).
+		Where(GreaterThan("PickedAt", picked)).
+		BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `UPDATE "carrot" SET "PickedAt" = ? WHERE "PickedAt" > ?;`, query)
+	assert.Equal(t, []any{before.Unix(), picked.Unix()}, args)
+
+	_, err = db.Exec(query, args...)
+	assert.NoError(t, err)
+
+	// Groups, ranges, and lists are all encoded.
+	_, args, err = Select[carrot]().
+		StoreTimeAs(TimeFormatUnix).
+		Where(Between("PickedAt", before, after)).
+		AndGroup(ActiveAt("PickedAt", "PickedAt", picked)).
+		Or(In("PickedAt", before, after)).
+		BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		[]any{before.Unix(), after.Unix(), picked.Unix(), picked.Unix(), before.Unix(), after.Unix()},
+		args,
+	)
+
+	carrots, err := Select[carrot]().
+		StoreTimeAs(TimeFormatUnix).
+		WhereGroup(ActiveAt("PickedAt", "PickedAt", before)).
+		Query(db)
+	assert.NoError(t, err)
+	assert.Len(t, carrots, 1)
+	assert.Equal(t, "soggy", carrots[0].Name)
+
+	result, err := Delete[carrot]().
+		StoreTimeAs(TimeFormatUnix).
+		Where(Equal("PickedAt", picked)).
+		Exec(db)
+	assert.NoError(t, err)
+	affected, err := result.RowsAffected()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+}
@@ -0,0 +1,76 @@
+package qubr
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// WithTransaction wraps WithTransactionOpts, using the default options of the driver.
+func WithTransaction(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	return WithTransactionOpts(ctx, db, nil, fn)
+}
+
+// WithTransactionOpts will run fn within a transaction, begun with the options provided, such as the isolation level.
+// If opts is nil, the default options of the driver are used. The transaction is committed if fn returns nil, and rolled
+// back if fn returns an error, or panics, in which case the panic continues once rolled back.
+// Example:
+//
+//	err := WithTransactionOpts(ctx, db, &sql.TxOptions{Isolation: sql.LevelSerializable}, func(tx *sql.Tx) error {
+//		_, err := Update[User]().
+//			Set("Name", "ollie").
+//			Where(Equal("ID", 42)).
+//			ExecContext(ctx, tx)
+//		return err
+//	})
+func WithTransactionOpts(ctx context.Context, db *sql.DB, opts *sql.TxOptions, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			// The original error is more useful than one from the rollback, so we keep both.
+			if rollbackErr := tx.Rollback(); rollbackErr != nil && err != nil {
+				err = errors.Join(err, rollbackErr)
+			}
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	committed = true
+	return tx.Commit()
+}
+
+// RunAll will execute the query of each builder in order, within a single transaction, so either all of them are
+// applied, or none are. The first error, whether from building or running a query, stops the rest from running, and
+// rolls back those which already ran.
+// Example:
+//
+//	err := RunAll(
+//		ctx,
+//		db,
+//		Insert[User]().Values(user),
+//		Update[Team]().Set("Size", 4).Where(Equal("ID", user.TeamID)),
+//		Delete[Invite]().Where(Equal("Email", user.Email)),
+//	)
+func RunAll(ctx context.Context, db *sql.DB, builders ...QueryBuilder) error {
+	return WithTransaction(ctx, db, func(tx *sql.Tx) error {
+		for _, b := range builders {
+			query, args, err := b.BuildQuery()
+			if err != nil {
+				return err
+			}
+
+			if _, err := execContext(ctx, tx, query, args); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
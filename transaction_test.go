@@ -0,0 +1,112 @@
+package qubr
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWithTransaction(t *testing.T) {
+	type bunny struct {
+		Name string
+		Age  int64
+	}
+
+	db := SetupMemoryTestDatabase(t, `CREATE TABLE "bunny" ("Name" TEXT, "Age" INT);`)
+
+	err := WithTransaction(context.Background(), db, func(tx *sql.Tx) error {
+		_, err := Insert[bunny]().Values(bunny{"ollie", 2}).Exec(tx)
+		return err
+	})
+	assert.NoError(t, err)
+
+	errTooOld := errors.New("too old")
+	err = WithTransaction(context.Background(), db, func(tx *sql.Tx) error {
+		_, err := Insert[bunny]().Values(bunny{"king ollie", 200}).Exec(tx)
+		assert.NoError(t, err)
+		return errTooOld
+	})
+	assert.ErrorIs(t, err, errTooOld)
+
+	assert.Panics(t, func() {
+		_ = WithTransaction(context.Background(), db, func(tx *sql.Tx) error {
+			_, err := Insert[bunny]().Values(bunny{"oliver", 1}).Exec(tx)
+			assert.NoError(t, err)
+			panic("carrot shortage")
+		})
+	})
+
+	bunnies, err := Select[bunny]().Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", 2}}, bunnies)
+}
+
+func TestWithTransactionOpts(t *testing.T) {
+	type bunny struct {
+		Name string
+	}
+
+	db := SetupMemoryTestDatabase(t, `CREATE TABLE "bunny" ("Name" TEXT);`)
+
+	err := WithTransactionOpts(
+		context.Background(),
+		db,
+		&sql.TxOptions{Isolation: sql.LevelSerializable},
+		func(tx *sql.Tx) error {
+			_, err := Insert[bunny]().Values(bunny{"ollie"}).Exec(tx)
+			return err
+		},
+	)
+	assert.NoError(t, err)
+
+	bunnies, err := Select[bunny]().Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie"}}, bunnies)
+}
+
+func TestRunAll(t *testing.T) {
+	type bunny struct {
+		Name string
+		Age  int64
+	}
+
+	db := SetupMemoryTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "Age" INT);`,
+		`INSERT INTO "bunny" VALUES('ollie', 2)`,
+	)
+
+	err := RunAll(
+		context.Background(),
+		db,
+		Insert[bunny]().Values(bunny{"oliver", 1}),
+		Update[bunny]().Set("Age", 3).Where(Equal("Name", "ollie")),
+	)
+	assert.NoError(t, err)
+
+	// The failing insert is last, so the delete and update before it are rolled back.
+	err = RunAll(
+		context.Background(),
+		db,
+		Delete[bunny]().Where(Equal("Name", "oliver")),
+		Update[bunny]().Set("Age", 200).Where(Equal("Name", "ollie")),
+		Insert[bunny]().Values(bunny{"king ollie", 200}).Into("missing"),
+	)
+	var queryErr QueryError
+	assert.ErrorAs(t, err, &queryErr)
+
+	// A builder which fails to build is rolled back the same way.
+	err = RunAll(
+		context.Background(),
+		db,
+		Delete[bunny]().Where(Equal("Name", "oliver")),
+		Insert[bunny](),
+	)
+	assert.ErrorIs(t, err, ErrNoInsertValues)
+
+	bunnies, err := Select[bunny]().Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []bunny{{"ollie", 3}, {"oliver", 1}}, bunnies)
+}
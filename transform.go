@@ -0,0 +1,65 @@
+package qubr
+
+import (
+	"reflect"
+	"sync"
+)
+
+// transform is a registered pair of conversions for a field's value, see RegisterTransform.
+type transform struct {
+	encode func(any) (any, error)
+	decode func(any) (any, error)
+}
+
+var (
+	transformsMu sync.RWMutex
+	transforms   = map[string]transform{}
+)
+
+// RegisterTransform will register a named pair of conversions, which can be applied to a field using the "transform"
+// option of its "db" tag. For example, the following field will use the transform registered as "aes":
+//
+//	SSN string `db:"ssn,transform=aes"`
+//
+// The encode function is applied to the field's value when it is given to the database, in inserts and updates.
+// The decode function is applied to the value scanned from the database, and the result must be assignable to the
+// field. Values given to a FieldOperation, or to UpdateBuilder.Set, are not transformed.
+// Registering a name which is already registered will replace it.
+func RegisterTransform(name string, encode func(any) (any, error), decode func(any) (any, error)) {
+	transformsMu.Lock()
+	defer transformsMu.Unlock()
+
+	transforms[name] = transform{encode, decode}
+}
+
+// fieldTransform will look up the transform of the field. If the field has no transform, then nil is returned.
+func fieldTransform(field reflect.StructField) (*transform, error) {
+	name, ok := structFieldOption(field, "transform")
+	if !ok {
+		return nil, nil
+	}
+
+	transformsMu.RLock()
+	defer transformsMu.RUnlock()
+
+	t, ok := transforms[name]
+	if !ok {
+		return nil, ErrUnknownTransform{name}
+	}
+
+	return &t, nil
+}
+
+// encodeFieldValue will encode the value of a field to be given to the database, using the field's transform if it
+// has one, otherwise, the TimeFormat.
+func encodeFieldValue(field reflect.StructField, v any, timeFormat TimeFormat) (any, error) {
+	t, err := fieldTransform(field)
+	if err != nil {
+		return nil, err
+	}
+	if t != nil {
+		return t.encode(v)
+	}
+
+	return timeFormat.encode(v), nil
+}
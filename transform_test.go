@@ -0,0 +1,85 @@
+package qubr
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTransformRoundTrip(t *testing.T) {
+	// Not exactly secure, but the bunnies will never guess.
+	reverse := func(s string) string {
+		r := []rune(s)
+		for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+			r[i], r[j] = r[j], r[i]
+		}
+		return string(r)
+	}
+	RegisterTransform(
+		"test-reverse",
+		func(v any) (any, error) { return reverse(v.(string)), nil },
+		func(v any) (any, error) { return reverse(v.(string)), nil },
+	)
+
+	type carrot struct {
+		Name       string
+		SecretFarm string `db:"secret_farm,transform=test-reverse"`
+	}
+
+	db := SetupMemoryTestDatabase(t, `CREATE TABLE "carrot" ("Name" TEXT, "secret_farm" TEXT);`)
+
+	_, err := Insert[carrot]().
+		Values(carrot{"crunchy", "ollie's farm"}).
+		Exec(db)
+	assert.NoError(t, err)
+
+	_, err = Update[carrot]().
+		SetStruct(carrot{"crunchier", "oliver's farm"}).
+		Where(Equal("Name", "crunchy")).
+		Exec(db)
+	assert.NoError(t, err)
+
+	type rawCarrot struct {
+		Name       string
+		SecretFarm string `db:"secret_farm"`
+	}
+	stored, err := QueryContext[rawCarrot](context.Background(), db, `SELECT * FROM "carrot";`)
+	assert.NoError(t, err)
+	assert.Equal(t, []rawCarrot{{"crunchier", "mraf s'revilo"}}, stored)
+
+	carrots, err := Select[carrot]().Query(db)
+	assert.NoError(t, err)
+	assert.Equal(t, []carrot{{"crunchier", "oliver's farm"}}, carrots)
+}
+
+func TestTransformEncodeError(t *testing.T) {
+	errNoCarrots := errors.New("no carrots allowed")
+	RegisterTransform(
+		"test-error",
+		func(v any) (any, error) { return nil, errNoCarrots },
+		func(v any) (any, error) { return v, nil },
+	)
+
+	type carrot struct {
+		Name string `db:"name,transform=test-error"`
+	}
+
+	_, _, err := Insert[carrot]().
+		Values(carrot{"crunchy"}).
+		BuildQuery()
+
+	assert.ErrorIs(t, err, errNoCarrots)
+}
+
+func TestTransformUnknown(t *testing.T) {
+	type carrot struct {
+		Name string `db:"name,transform=test-unknown"`
+	}
+
+	_, _, err := Update[carrot]().
+		SetStruct(carrot{"crunchy"}).
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrUnknownTransform{"test-unknown"}, err)
+}
@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
+	"time"
 )
 
 // UpdateBuilder is a QueryBuilder for building SQL UPDATE queries.
@@ -22,10 +24,22 @@ import (
 type UpdateBuilder[T any] struct {
 	from tableName
 
-	literalValue *T
+	literalValue  *T
+	literalFields []string // Names of the fields of literalValue to set, or all of them when nil.
+	setValues     []setValue
 
 	fieldOperationTree fieldOperationTree
 
+	timeFormat TimeFormat
+
+	comment string
+
+	timeout time.Duration
+
+	strictExportedFields bool
+
+	safeMode bool
+
 	err error
 }
 
@@ -54,27 +68,114 @@ func (b UpdateBuilder[T]) Into(tableName string) UpdateBuilder[T] {
 
 func (b UpdateBuilder[T]) SetStruct(t T) UpdateBuilder[T] {
 	b.literalValue = &t
+	b.literalFields = nil
+	return b
+}
+
+// SetDiff will set only the fields which differ between before and after, to their value in after. Like
+// UpdateBuilder.SetStruct, this replaces any struct which was set before, and UpdateBuilder.Set will override it.
+// Each exported field is compared using reflect.DeepEqual, so pointers are equal if the values they point to are equal,
+// and a nil slice is not equal to an empty one. Unexported fields are ignored, as they are never set.
+// If nothing differs, then there is nothing to set, and ErrNoSetStatement is returned when building.
+func (b UpdateBuilder[T]) SetDiff(before, after T) UpdateBuilder[T] {
+	if err := checkStructType(reflect.TypeFor[T]()); err != nil {
+		b.err = err
+		return b
+	}
+
+	beforeValue, afterValue := reflect.ValueOf(before), reflect.ValueOf(after)
+
+	changed := []string{}
+	for _, f := range exportedFields(reflect.TypeFor[T]()) {
+		beforeField := beforeValue.FieldByIndex(f.Index).Interface()
+		afterField := afterValue.FieldByIndex(f.Index).Interface()
+		if !reflect.DeepEqual(beforeField, afterField) {
+			changed = append(changed, f.Name)
+		}
+	}
+
+	b.literalValue = &after
+	b.literalFields = changed
 	return b
 }
 
+// Set will set a single field to the value given. This can be used along with UpdateBuilder.SetStruct, where the
+// struct provides the base set of fields. If the field is already being set by the struct, or by an earlier call to
+// Set, then the value is overridden in place. Otherwise, the field is added after the existing fields.
+func (b UpdateBuilder[T]) Set(field string, v any) UpdateBuilder[T] {
+	b.setValues = append(slices.Clip(b.setValues), setValue{field, v})
+	return b
+}
+
+// SetSubquery will set a single field to the result of a scalar subquery, such as a SelectBuilder which selects a
+// single field. Like UpdateBuilder.Set, this will override the field if it is already being set.
+// The args of the subquery are placed along with the other set values, before those of the where clause.
+// Equivalent SQL will be:
+//
+//	SET "field" = (SELECT ...)
+func (b UpdateBuilder[T]) SetSubquery(field string, sub QueryBuilder) UpdateBuilder[T] {
+	return b.Set(field, ScalarSubquery(sub))
+}
+
 // Where will apply a FieldOperation as the initial comparison operator of a where clause.
 // Where cannot be called more than once, use UpdateBuilder.And or UpdateBuilder.Or for further filtering.
 func (b UpdateBuilder[T]) Where(op FieldOperation) UpdateBuilder[T] {
-	if b.fieldOperationTree != emptyFieldOperationTree {
+	if !b.fieldOperationTree.isEmpty() {
 		b.err = ErrDoubleWhereClause
 		return b
 	}
 
-	b.fieldOperationTree.op = op
+	b.fieldOperationTree = fieldOperationTree{entries: []fieldOperationEntry{{op: op}}}
+
+	return b
+}
+
+// ByIDs will apply an In on the column, for each of the ids, as the initial condition of a where clause. This is the
+// same as using UpdateBuilder.Where with In, which splits the ids across many INs, joined by OR, when there are more than 500.
+// Unlike In, a single id which is a QueryBuilder is not used as a subquery. No ids will result in ErrNoIDs.
+// Like UpdateBuilder.Where, this cannot be called more than once, or along with UpdateBuilder.Where.
+// Equivalent SQL will be:
+//
+//	WHERE "column" IN (?, ...)
+//	WHERE ("column" IN (?, ...) OR "column" IN (?, ...))
+func (b UpdateBuilder[T]) ByIDs(column string, ids ...any) UpdateBuilder[T] {
+	if !b.fieldOperationTree.isEmpty() {
+		b.err = ErrDoubleWhereClause
+		return b
+	}
+
+	tree, err := newIDsFieldOperationTree(column, ids)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	b.fieldOperationTree = tree
+	return b
+}
+
+// WhereGroup will apply a Group as the initial condition of a where clause, built using fn.
+// Like UpdateBuilder.Where, this cannot be called more than once, or along with UpdateBuilder.Where.
+func (b UpdateBuilder[T]) WhereGroup(fn func(g *Group)) UpdateBuilder[T] {
+	if !b.fieldOperationTree.isEmpty() {
+		b.err = ErrDoubleWhereClause
+		return b
+	}
+
+	group, err := newFieldOperationGroup(fn)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	b.fieldOperationTree = fieldOperationTree{entries: []fieldOperationEntry{{group: group}}}
 
 	return b
 }
 
 // And will apply an AND to the existing where clause. UpdateBuilder.Where must be called before this.
 func (b UpdateBuilder[T]) And(op FieldOperation) UpdateBuilder[T] {
-	err := appendToFieldOperationTree(&b.fieldOperationTree, func(next *fieldOperationTree) {
-		next.and = &fieldOperationTree{op: op}
-	})
+	err := appendToFieldOperationTree(&b.fieldOperationTree, fieldOperationEntry{connector: connectorAnd, op: op})
 	if err != nil {
 		b.err = err
 	}
@@ -83,15 +184,115 @@ func (b UpdateBuilder[T]) And(op FieldOperation) UpdateBuilder[T] {
 
 // Or will apply an OR to the existing where clause. UpdateBuilder.Where must be called before this.
 func (b UpdateBuilder[T]) Or(op FieldOperation) UpdateBuilder[T] {
-	err := appendToFieldOperationTree(&b.fieldOperationTree, func(next *fieldOperationTree) {
-		next.or = &fieldOperationTree{op: op}
-	})
+	err := appendToFieldOperationTree(&b.fieldOperationTree, fieldOperationEntry{connector: connectorOr, op: op})
+	if err != nil {
+		b.err = err
+	}
+	return b
+}
+
+// AndRaw will apply an AND to the existing where clause, with a condition of raw SQL, and the args for any of its
+// placeholders. The condition is rendered within parentheses. UpdateBuilder.Where must be called before this.
+// The expression is placed into the query as it is, so it must never contain untrusted input. Instead, use
+// placeholders, and pass the input as args.
+func (b UpdateBuilder[T]) AndRaw(expr string, args ...any) UpdateBuilder[T] {
+	raw, err := newRawCondition(expr, args)
+	if err == nil {
+		err = appendToFieldOperationTree(&b.fieldOperationTree, fieldOperationEntry{connector: connectorAnd, raw: raw})
+	}
+	if err != nil {
+		b.err = err
+	}
+	return b
+}
+
+// OrRaw will apply an OR to the existing where clause, with a condition of raw SQL, and the args for any of its
+// placeholders. The condition is rendered within parentheses. UpdateBuilder.Where must be called before this.
+// Like UpdateBuilder.AndRaw, the expression must never contain untrusted input.
+func (b UpdateBuilder[T]) OrRaw(expr string, args ...any) UpdateBuilder[T] {
+	raw, err := newRawCondition(expr, args)
+	if err == nil {
+		err = appendToFieldOperationTree(&b.fieldOperationTree, fieldOperationEntry{connector: connectorOr, raw: raw})
+	}
+	if err != nil {
+		b.err = err
+	}
+	return b
+}
+
+// NoWhere will clear any where clause that has been applied, so that a new one can be applied using UpdateBuilder.Where.
+// This is useful for deriving an unfiltered builder from one with filters. If there is no where clause, this does
+// nothing.
+// Be aware that an UpdateBuilder with no where clause will update every row in the table.
+func (b UpdateBuilder[T]) NoWhere() UpdateBuilder[T] {
+	b.fieldOperationTree = fieldOperationTree{}
+	return b
+}
+
+// AndGroup will apply an AND to the existing where clause, with a Group built using fn.
+// The Group will be rendered within parentheses. UpdateBuilder.Where must be called before this.
+func (b UpdateBuilder[T]) AndGroup(fn func(g *Group)) UpdateBuilder[T] {
+	group, err := newFieldOperationGroup(fn)
+	if err == nil {
+		err = appendToFieldOperationTree(&b.fieldOperationTree, fieldOperationEntry{connector: connectorAnd, group: group})
+	}
+	if err != nil {
+		b.err = err
+	}
+	return b
+}
+
+// OrGroup will apply an OR to the existing where clause, with a Group built using fn.
+// The Group will be rendered within parentheses. UpdateBuilder.Where must be called before this.
+func (b UpdateBuilder[T]) OrGroup(fn func(g *Group)) UpdateBuilder[T] {
+	group, err := newFieldOperationGroup(fn)
+	if err == nil {
+		err = appendToFieldOperationTree(&b.fieldOperationTree, fieldOperationEntry{connector: connectorOr, group: group})
+	}
 	if err != nil {
 		b.err = err
 	}
 	return b
 }
 
+// StoreTimeAs will set the TimeFormat which time.Time fields are stored as, and which time.Time values in the where
+// clause are compared as. By default, this is TimeFormatNative.
+func (b UpdateBuilder[T]) StoreTimeAs(f TimeFormat) UpdateBuilder[T] {
+	b.timeFormat = f
+	return b
+}
+
+// WithComment will place a comment at the start of the query, which is useful for tracing queries back to the code
+// which ran them, such as in slow query logs. Anything which would close the comment early is removed.
+// Equivalent SQL will be:
+//
+//	/* comment */ ...
+func (b UpdateBuilder[T]) WithComment(comment string) UpdateBuilder[T] {
+	b.comment = comment
+	return b
+}
+
+// Timeout will set a deadline for running the query, when it is run without a context, such as by UpdateBuilder.Exec.
+// If a context is given, such as to UpdateBuilder.ExecContext, then the timeout is not used.
+func (b UpdateBuilder[T]) Timeout(d time.Duration) UpdateBuilder[T] {
+	b.timeout = d
+	return b
+}
+
+// StrictExportedFields will cause UpdateBuilder.BuildQuery to return an ErrUnexportedField if T has any unexported fields.
+// By default, unexported fields are silently skipped.
+func (b UpdateBuilder[T]) StrictExportedFields() UpdateBuilder[T] {
+	b.strictExportedFields = true
+	return b
+}
+
+// SafeMode will cause UpdateBuilder.BuildQuery to return an ErrRawInSafeMode if any method which places raw SQL into the query
+// has been used, regardless of whether it was called before or after this. See SetSafeMode for these methods.
+func (b UpdateBuilder[T]) SafeMode() UpdateBuilder[T] {
+	b.safeMode = true
+	return b
+}
+
 // BuildQuery will construct the SQL query UpdateBuilder is currently representing.
 // User input will utilize placeholders, and the values of the input will be in the 2nd return value, args.
 // If there was an issue in the construction of UpdateBuilder, then the 3rd return value, err will not non-nil.
@@ -103,56 +304,134 @@ func (b UpdateBuilder[T]) BuildQuery() (query string, args []any, err error) {
 	if b.err != nil {
 		return "", nil, b.err
 	}
+	if err := checkStructType(reflect.TypeFor[T]()); err != nil {
+		return "", nil, err
+	}
+	if err := checkWritable[T](); err != nil {
+		return "", nil, err
+	}
+	if b.strictExportedFields {
+		if err := checkExportedFields(reflect.TypeFor[T]()); err != nil {
+			return "", nil, err
+		}
+	}
+	if (b.safeMode || safeMode.Load()) && b.hasRaw() {
+		return "", nil, ErrRawInSafeMode
+	}
+	if err := checkDuplicateColumns(reflect.TypeFor[T]()); err != nil {
+		return "", nil, err
+	}
 
 	tableName := b.from.String()
 
 	// SET "X" = ?, "Y" = ?
 	var setStmt string
 	{
-		if b.literalValue == nil {
+		if b.literalValue == nil && len(b.setValues) == 0 {
+			return "", nil, ErrNoSetStatement
+		}
+
+		// Start with the struct's exported fields, then override or add with the individually set fields.
+		var values []setValue
+		if b.literalValue != nil {
+			if err := validate(*b.literalValue); err != nil {
+				return "", nil, err
+			}
+
+			insertType := reflect.TypeFor[T]()
+			insertValue := reflect.ValueOf(*b.literalValue)
+			for i := range insertValue.NumField() {
+				f := insertType.Field(i)
+				if !f.IsExported() {
+					continue
+				}
+				if b.literalFields != nil && !slices.Contains(b.literalFields, f.Name) {
+					continue
+				}
+
+				v, err := encodeFieldValue(f, insertValue.Field(i).Interface(), b.timeFormat)
+				if err != nil {
+					return "", nil, err
+				}
+
+				values = append(values, setValue{structFieldName(f), v})
+			}
+		}
+		for _, v := range b.setValues {
+			v.field = fieldColumnName[T](v.field)
+			v.value = b.timeFormat.encode(v.value)
+
+			i := slices.IndexFunc(values, func(existing setValue) bool { return existing.field == v.field })
+			if i < 0 {
+				values = append(values, v)
+				continue
+			}
+
+			values[i] = v
+		}
+		if len(values) == 0 {
 			return "", nil, ErrNoSetStatement
 		}
 
 		sb := strings.Builder{}
 		sb.WriteString(" SET ")
+		for _, v := range values {
+			if subquery, ok := v.value.(Subquery); ok {
+				query, subqueryArgs, err := subquery.queryData()
+				if err != nil {
+					return "", nil, err
+				}
 
-		// We can calculate the set statement query and the args in one pass.
-		// We don't need to keep a reference to which index we are on, so passing over exported fields is fine.
-
-		insertType := reflect.TypeFor[T]()
-		insertValue := reflect.ValueOf(*b.literalValue)
-		numField := insertValue.NumField()
-		for i := range numField {
-			f := insertType.Field(i)
-			if !f.IsExported() {
+				sb.WriteString(fmt.Sprintf(`"%s" = %s, `, v.field, query))
+				args = append(args, subqueryArgs...)
 				continue
 			}
 
-			sb.WriteString(fmt.Sprintf(`"%s" = ?, `, structFieldName(f)))
-			args = append(args, insertValue.Field(i).Interface())
+			sb.WriteString(fmt.Sprintf(`"%s" = ?, `, v.field))
+			args = append(args, v.value)
 		}
 
 		setStmt = strings.TrimSuffix(sb.String(), ", ")
 	}
 
-	whereClause, whereArgs := b.fieldOperationTree.buildQuery()
+	whereClause, whereArgs, err := b.fieldOperationTree.mapFieldNames(fieldColumnName[T]).encodeTimes(b.timeFormat).buildQuery()
+	if err != nil {
+		return "", nil, err
+	}
 	args = append(args, whereArgs...)
 
-	return fmt.Sprintf("UPDATE %s%s%s;", tableName, setStmt, whereClause), args, nil
+	return fmt.Sprintf("%sUPDATE %s%s%s;", commentPrefix(b.comment), tableName, setStmt, whereClause), args, nil
+}
+
+// hasRaw will report whether the update has any raw SQL, within its conditions or the subqueries it sets fields to.
+func (b UpdateBuilder[T]) hasRaw() bool {
+	return b.fieldOperationTree.hasRaw() || slices.ContainsFunc(b.setValues, func(v setValue) bool {
+		subquery, ok := v.value.(Subquery)
+		return ok && subquery.hasRaw()
+	})
 }
 
 // Exec wraps UpdateBuilder.ExecContext, which will execute the update query represented by the UpdateBuilder.
-func (b UpdateBuilder[T]) Exec(db *sql.DB) (sql.Result, error) {
-	return b.ExecContext(context.Background(), db)
+func (b UpdateBuilder[T]) Exec(db Executor) (sql.Result, error) {
+	ctx, cancel := timeoutContext(b.timeout)
+	defer cancel()
+
+	return b.ExecContext(ctx, db)
 }
 
 // ExecContext will execute the update query represented by the UpdateBuilder.
-// This will execute using the provided sql.DB, and the response is simply passed back.
-func (b UpdateBuilder[T]) ExecContext(ctx context.Context, db *sql.DB) (sql.Result, error) {
+// This will execute using the provided Executor, and the response is simply passed back.
+func (b UpdateBuilder[T]) ExecContext(ctx context.Context, db Executor) (sql.Result, error) {
 	query, args, err := b.BuildQuery()
 	if err != nil {
 		return nil, err
 	}
 
-	return db.ExecContext(ctx, query, args...)
+	return execContext(ctx, db, query, args)
+}
+
+// setValue is a single field of a SET statement, and the value it is set to.
+type setValue struct {
+	field string
+	value any
 }
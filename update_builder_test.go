@@ -2,6 +2,7 @@ package qubr
 
 import (
 	"github.com/stretchr/testify/assert"
+	"reflect"
 	"testing"
 )
 
@@ -102,3 +103,196 @@ func TestUpdateAndExec(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, int64(1), affected)
 }
+
+func TestUpdateWithSetOnly(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	query, args, err := Update[bunny]().
+		Set("EarLength", 31.5).
+		Where(Equal("Name", "king oliver")).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `UPDATE "bunny" SET "EarLength" = ? WHERE "Name" = ?;`, query)
+	assert.Equal(t, []any{31.5, "king oliver"}, args)
+}
+
+func TestUpdateWithSetStructAndSet(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	query, args, err := Update[bunny]().
+		Set("EarLength", 1.0).
+		SetStruct(bunny{"king oliver", 30}).
+		Set("UpdatedAt", "today").
+		Set("EarLength", 31.5).
+		Where(Equal("Name", "mr. oliver")).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `UPDATE "bunny" SET "Name" = ?, "EarLength" = ?, "UpdatedAt" = ? WHERE "Name" = ?;`, query)
+	assert.Equal(t, []any{"king oliver", 31.5, "today", "mr. oliver"}, args)
+}
+
+func TestUpdateStrictExportedFields(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+
+		age int64
+	}
+
+	_, _, err := Update[bunny]().
+		StrictExportedFields().
+		Set("EarLength", 21).
+		Where(Equal("Name", "oliver")).
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrUnexportedField{"age"}, err)
+
+	type exportedBunny struct {
+		Name      string
+		EarLength float64
+	}
+
+	query, _, err := Update[exportedBunny]().
+		StrictExportedFields().
+		Set("EarLength", 21).
+		Where(Equal("Name", "oliver")).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `UPDATE "exportedBunny" SET "EarLength" = ? WHERE "Name" = ?;`, query)
+}
+
+func TestUpdateNotAStruct(t *testing.T) {
+	_, _, err := Update[string]().
+		SetStruct("king oliver").
+		BuildQuery()
+
+	assert.ErrorIs(t, ErrNotAStruct{reflect.TypeFor[string]()}, err)
+}
+
+func TestUpdateWithSetSubquery(t *testing.T) {
+	type bunny struct {
+		Name      string
+		EarLength float64
+		Carrots   int64
+	}
+	type carrot struct {
+		BunnyName string
+		Crunchy   bool
+	}
+
+	builder := Update[bunny]().
+		Set("EarLength", 31.5).
+		SetSubquery("Carrots", Select[carrot]().WithFields("BunnyName").Where(IsTrue("Crunchy")).Limit(1)).
+		Where(Equal("Name", "oliver"))
+
+	query, args, err := builder.BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`UPDATE "bunny" SET "EarLength" = ?, "Carrots" = (SELECT "BunnyName" FROM "carrot" WHERE "Crunchy" = ? LIMIT ?) `+
+			`WHERE "Name" = ?;`,
+		query,
+	)
+	assert.Equal(t, []any{31.5, true, uint64(1), "oliver"}, args)
+}
+
+func TestUpdateWithSetSubqueryAndExec(t *testing.T) {
+	type bunny struct {
+		Name    string
+		Carrots int64
+	}
+
+	db := SetupMemoryTestDatabase(
+		t,
+		`CREATE TABLE "bunny" ("Name" TEXT, "Carrots" INT);`,
+		`CREATE TABLE "carrot_count" ("Count" INT);`,
+		`INSERT INTO "bunny" VALUES('oliver', 0)`,
+		`INSERT INTO "carrot_count" VALUES(12)`,
+	)
+
+	type carrotCount struct {
+		Count int64
+	}
+
+	_, err := Update[bunny]().
+		SetSubquery("Carrots", Select[carrotCount]().From("carrot_count")).
+		Where(Equal("Name", "oliver")).
+		Exec(db)
+	assert.NoError(t, err)
+
+	oliver, err := Select[bunny]().GetOne(db)
+	assert.NoError(t, err)
+	assert.Equal(t, &bunny{"oliver", 12}, oliver)
+}
+
+func TestUpdateWithDuplicateColumn(t *testing.T) {
+	type bunny struct {
+		Name     string
+		Nickname string `db:"Name"`
+	}
+
+	_, _, err := Update[bunny]().
+		SetStruct(bunny{"ollie", "ols"}).
+		Where(Equal("Name", "ollie")).
+		BuildQuery()
+
+	assert.ErrorIs(t, err, ErrDuplicateColumn{"Name", [2]string{"Name", "Nickname"}})
+}
+
+func TestUpdateSetDiff(t *testing.T) {
+	type bunny struct {
+		ID        int64
+		Name      string
+		EarLength float64
+		Foods     []string
+		secret    string
+	}
+
+	before := bunny{1, "ollie", 15, []string{"carrot"}, "likes kale"}
+	after := before
+	after.EarLength = 16
+	after.Foods = []string{"carrot"}
+	after.secret = "loves kale"
+
+	query, args, err := Update[bunny]().
+		SetDiff(before, after).
+		Where(Equal("ID", after.ID)).
+		BuildQuery()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `UPDATE "bunny" SET "EarLength" = ? WHERE "ID" = ?;`, query)
+	assert.Equal(t, []any{16.0, int64(1)}, args)
+
+	_, _, err = Update[bunny]().
+		SetDiff(before, before).
+		Where(Equal("ID", before.ID)).
+		BuildQuery()
+
+	assert.ErrorIs(t, err, ErrNoSetStatement)
+}
+
+func TestUpdateByIDs(t *testing.T) {
+	type bunny struct {
+		ID   int64
+		Name string
+	}
+
+	query, args, err := Update[bunny]().
+		Set("Name", "oliver").
+		ByIDs("ID", int64(1), int64(2)).
+		And(NotEqual("Name", "oliver")).
+		BuildQuery()
+	assert.NoError(t, err)
+	assert.Equal(t, `UPDATE "bunny" SET "Name" = ? WHERE "ID" IN (?, ?) AND "Name" <> ?;`, query)
+	assert.Equal(t, []any{"oliver", int64(1), int64(2), "oliver"}, args)
+}